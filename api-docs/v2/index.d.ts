@@ -78,13 +78,15 @@ export enum BALANCERS {
   BALANCER_MURMUR2 = "balancer_murmur2",
 }
 
-type BalancerFunction = (key: Uint8Array, partitionCount: number) => number;
+/* Custom balancer, called with the message key and an array of the topic's partition IDs. */
+type BalancerFunction = (key: Uint8Array, partitions: number[]) => number;
 
 /* Consumer group balancing strategies for consuming messages. */
 export enum GROUP_BALANCERS {
   GROUP_BALANCER_RANGE = "group_balancer_range",
   GROUP_BALANCER_ROUND_ROBIN = "group_balancer_round_robin",
   GROUP_BALANCER_RACK_AFFINITY = "group_balancer_rack_affinity",
+  GROUP_BALANCER_COOPERATIVE_STICKY = "group_balancer_cooperative_sticky",
 }
 
 /* Schema types used in identifying schema and data type in serdes. */
@@ -96,6 +98,58 @@ export enum SCHEMA_TYPES {
   SCHEMA_TYPE_PROTOBUF = "PROTOBUF",
 }
 
+/* What a consumer does with a message its deserializer cannot decode. */
+export enum DECODE_ERROR {
+  DECODE_ERROR_THROW = "throw", // default
+  DECODE_ERROR_SKIP = "skip",
+  DECODE_ERROR_RAW = "raw",
+}
+
+/* Resources whose configs AdminClient describes and alters, and that ACLs apply to. */
+export enum RESOURCE_TYPE {
+  RESOURCE_TOPIC = "resource_topic",
+  RESOURCE_BROKER = "resource_broker", // The cluster, named "kafka-cluster", in ACLs
+  RESOURCE_GROUP = "resource_group", // ACLs only
+  RESOURCE_ANY = "resource_any", // ACL filters only
+}
+
+/* How the resource name of an ACL binding matches resources. */
+export enum PATTERN_TYPE {
+  PATTERN_TYPE_LITERAL = "pattern_type_literal", // default in bindings
+  PATTERN_TYPE_PREFIXED = "pattern_type_prefixed",
+  PATTERN_TYPE_MATCH = "pattern_type_match", // ACL filters only
+  PATTERN_TYPE_ANY = "pattern_type_any", // ACL filters only
+}
+
+export enum ACL_OPERATION {
+  ACL_OPERATION_ANY = "acl_operation_any", // ACL filters only
+  ACL_OPERATION_ALL = "acl_operation_all",
+  ACL_OPERATION_READ = "acl_operation_read",
+  ACL_OPERATION_WRITE = "acl_operation_write",
+  ACL_OPERATION_CREATE = "acl_operation_create",
+  ACL_OPERATION_DELETE = "acl_operation_delete",
+  ACL_OPERATION_ALTER = "acl_operation_alter",
+  ACL_OPERATION_DESCRIBE = "acl_operation_describe",
+  ACL_OPERATION_CLUSTER_ACTION = "acl_operation_cluster_action",
+  ACL_OPERATION_DESCRIBE_CONFIGS = "acl_operation_describe_configs",
+  ACL_OPERATION_ALTER_CONFIGS = "acl_operation_alter_configs",
+  ACL_OPERATION_IDEMPOTENT_WRITE = "acl_operation_idempotent_write",
+}
+
+export enum ACL_PERMISSION {
+  ACL_PERMISSION_ANY = "acl_permission_any", // ACL filters only
+  ACL_PERMISSION_ALLOW = "acl_permission_allow",
+  ACL_PERMISSION_DENY = "acl_permission_deny",
+}
+
+/* Operations of `AdminClient.incrementalAlterConfigs()`. */
+export enum CONFIG_OP {
+  CONFIG_OP_SET = "config_op_set", // default
+  CONFIG_OP_DELETE = "config_op_delete",
+  CONFIG_OP_APPEND = "config_op_append",
+  CONFIG_OP_SUBTRACT = "config_op_subtract",
+}
+
 /* Time units for use in timeouts. */
 export enum TIME {
   NANOSECOND = 1,
@@ -138,6 +192,17 @@ export interface TLSConfig {
 export interface WriterConfig {
   brokers: string[];
   topic: string;
+  /** Enables the transactional API. Requires `requiredAcks` to be unset or `-1`. */
+  transactionalId?: string;
+  /** Maximum number of pending `produceAsync` calls. Defaults to 1000. */
+  maxInFlight?: number;
+  /**
+   * Raw librdkafka properties, applied over the typed options. Keys managed by
+   * xk6-kafka, such as `go.delivery.reports`, are rejected.
+   */
+  config?: Record<string, string | number | boolean>;
+  /** Reject unknown `config` keys instead of logging a warning. */
+  strictConfig?: boolean;
   autoCreateTopic: boolean;
   balancer: BALANCERS | BalancerFunction;
   maxAttempts: number;
@@ -151,6 +216,10 @@ export interface WriterConfig {
   sasl: SASLConfig;
   tls: TLSConfig;
   connectLogger: boolean;
+  /** Encode the keys passed to `produce` in Go, so they can be plain objects. */
+  keySerializer?: SerializerConfig;
+  /** Encode the values passed to `produce` in Go, so they can be plain objects. */
+  valueSerializer?: SerializerConfig;
 }
 
 /**
@@ -159,11 +228,15 @@ export interface WriterConfig {
  */
 export interface Message {
   topic: string;
+  /** When set, the message is written to this partition and the balancer is skipped. */
   partition: number;
   offset: number;
-  highwaterMark: number;
-  key: Uint8Array;
-  value: Uint8Array;
+  /** Offset after the last message in the partition, as last fetched by the consumer. */
+  highWaterMark: number;
+  /** Decoded by the consumer's `keyDeserializer`, or encoded by the producer's `keySerializer`. */
+  key: Uint8Array | string | object;
+  /** Decoded by the consumer's `valueDeserializer`, or encoded by the producer's `valueSerializer`. */
+  value: Uint8Array | string | object;
   headers: Map<string, any>;
   time: Date;
 }
@@ -182,9 +255,60 @@ export interface SchemaRegistryConfig {
   tls: TLSConfig;
 }
 
+/* Decodes the keys or values of consumed messages before they are returned. */
+export interface DeserializerConfig {
+  schemaType: SCHEMA_TYPES;
+  /**
+   * Resolves the schema from the id in the wire format prefix. Required for
+   * `SCHEMA_TYPE_AVRO` and `SCHEMA_TYPE_PROTOBUF`; JSON without it is decoded
+   * as plain JSON.
+   */
+  schemaRegistry?: SchemaRegistryConfig;
+  /**
+   * `TOPIC_NAME_STRATEGY` only accepts schemas of the `<topic>-key` or
+   * `<topic>-value` subject. Otherwise the id is looked up across all subjects.
+   */
+  subjectNameStrategy?: SUBJECT_NAME_STRATEGY;
+  /** Skip the message, throw from `consume()` or return the bytes. Defaults to `DECODE_ERROR_THROW`. */
+  onError?: DECODE_ERROR;
+}
+
+/* Encodes the keys or values passed to `produce`. */
+export interface SerializerConfig {
+  schemaType: SCHEMA_TYPES;
+  /** Required for `SCHEMA_TYPE_AVRO`, `SCHEMA_TYPE_PROTOBUF` and `autoRegister`; JSON without it is plain JSON. */
+  schemaRegistry?: SchemaRegistryConfig;
+  /** Subject of the schema. Without it, it is derived from the topic of each message. */
+  subject?: string;
+  subjectNameStrategy?: SUBJECT_NAME_STRATEGY;
+  /** Registered with `autoRegister`. The record name strategies also take the record name from it. */
+  schema?: string;
+  /** Protobuf message to encode, and record name of the record name strategies. */
+  messageName?: string;
+  /** Register `schema` under the subject instead of using its latest version. */
+  autoRegister?: boolean;
+}
+
 /* Configuration for producing messages to a topic. */
 export interface ProduceConfig {
   messages: Message[];
+  /** Wait for every message and return one `DeliveryReport` per message. */
+  deliveryReports?: boolean;
+}
+
+/* Where the cluster stored a produced message. */
+export interface DeliveryReport {
+  topic: string;
+  partition: number;
+  /** -1001 when `requiredAcks` is 0, since the broker does not return offsets. */
+  offset: number;
+  /**
+   * RFC 3339 timestamp the message was sent with: its `time`, or the time it was produced.
+   * This is not the broker's log append time.
+   */
+  time: string;
+  /** Milliseconds from handing the message to librdkafka until its delivery report arrived. */
+  latency: number;
 }
 
 /* Configuration for creating a Reader instance. */
@@ -201,9 +325,17 @@ export interface ReaderConfig {
   maxBytes: number;
   readBatchTimeout: number;
   maxWait: string;
+  /** Nanoseconds between background samples of the lag of every assigned partition. Disabled when not positive. */
   readLagInterval: number;
+  /**
+   * Mapped to librdkafka's `partition.assignment.strategy`.
+   * `GROUP_BALANCER_COOPERATIVE_STICKY` cannot be combined with the others.
+   */
   groupBalancers: GROUP_BALANCERS[];
+  /** Rack of the consumer, sent as `client.rack`. Required by `GROUP_BALANCER_RACK_AFFINITY`. */
+  rack?: string;
   heartbeatInterval: number;
+  /** Group consumers commit consumed offsets automatically at this interval. */
   commitInterval: number;
   partitionWatchInterval: number;
   watchPartitionChanges: boolean;
@@ -216,10 +348,45 @@ export interface ReaderConfig {
   readBackoffMax: number;
   connectLogger: boolean;
   maxAttempts: number;
+  /** Defaults to `ISOLATION_LEVEL_READ_COMMITTED`, librdkafka's default. */
   isolationLevel: ISOLATION_LEVEL;
   offset: number;
   sasl: SASLConfig;
   tls: TLSConfig;
+  /**
+   * Raw librdkafka properties, applied over the typed options. Keys managed by
+   * xk6-kafka, such as `go.delivery.reports`, are rejected.
+   */
+  config?: Record<string, string | number | boolean>;
+  /** Reject unknown `config` keys instead of logging a warning. */
+  strictConfig?: boolean;
+  /**
+   * Called from `consume()` or `close()` when the group assigns or revokes
+   * partitions, before the assignment changes. Errors it throws fail the
+   * `consume()` call. It must not close the consumer.
+   */
+  onRebalance?: (event: RebalanceEvent) => void;
+  /**
+   * Partitions to read without joining a group, instead of `topic` and
+   * `partition`. Offsets that are not positive start from `startOffset`.
+   */
+  assignments?: TopicPartition[];
+  /**
+   * Start partitions without a positive offset from their first message at or
+   * after this time. Group consumers only apply it on the first assignment of
+   * each partition; later ones resume from the committed offsets.
+   */
+  startTime?: Date | string;
+  /** Decode message keys in Go instead of returning bytes. */
+  keyDeserializer?: DeserializerConfig;
+  /** Decode message values in Go instead of returning bytes. */
+  valueDeserializer?: DeserializerConfig;
+  /**
+   * Static member identity, sent as `group.instance.id`. A consumer that
+   * rejoins with it within `sessionTimeout` does not trigger a rebalance.
+   * `{vu}` is replaced with the VU id. Requires `groupId`.
+   */
+  groupInstanceId?: string;
 }
 
 /** Configuration for Consume method. */
@@ -235,6 +402,41 @@ export interface ConsumeConfig {
    * passed.
    * */
   expectTimeout: boolean;
+  /**
+   * Drop messages in Go before they are returned. Dropped messages do not
+   * count towards `maxMessages` and are counted in `kafka_reader_filtered_count`.
+   * A call with a filter reads for at most `maxWait`, then times out like a
+   * call on an idle topic, returning the matched messages with `expectTimeout`.
+   */
+  filter?: MessageFilter;
+}
+
+/** Conditions a consumed message must all match to be returned. */
+export interface MessageFilter {
+  /** Header names and the value each must have. */
+  headerEquals?: { [name: string]: string };
+  /** Headers that must be present, with any value. */
+  headerExists?: string[];
+  keyPrefix?: string;
+  /** Matched against the key, anywhere in it unless anchored. */
+  keyRegex?: string;
+  partitions?: number[];
+  /** Earliest message time, inclusive. */
+  timestampFrom?: Date | string;
+  /** Latest message time, exclusive. */
+  timestampTo?: Date | string;
+}
+
+/** Configuration for `Consumer.start()`. */
+export interface StreamConfig {
+  /** Largest batch passed to the handler. Defaults to 100. */
+  maxMessages?: number;
+  /** How long a batch collects messages before it is handed over with fewer. Defaults to `"100ms"`. */
+  maxWait?: string | number;
+  /** Batches waiting for the handler before the consumer stops reading. Defaults to 4. */
+  bufferSize?: number;
+  /** If true, message RFC3339 timestamps carry nanosecond precision. */
+  nanoPrecision?: boolean;
 }
 
 /* Configuration for creating a Connector instance for working with topics. */
@@ -243,6 +445,13 @@ export interface ConnectionConfig {
   brokers?: string[];
   sasl: SASLConfig;
   tls: TLSConfig;
+  /**
+   * Raw librdkafka properties, applied over the typed options. Keys managed by
+   * xk6-kafka, such as `go.delivery.reports`, are rejected.
+   */
+  config?: Record<string, string | number | boolean>;
+  /** Reject unknown `config` keys instead of logging a warning. */
+  strictConfig?: boolean;
 }
 
 /* ReplicaAssignment among kafka brokers for this topic partitions. */
@@ -257,6 +466,148 @@ export interface ConfigEntry {
   configValue: string;
 }
 
+/* A topic, or a broker named by its id, such as `"1"`. */
+export interface ConfigResource {
+  type: RESOURCE_TYPE;
+  name: string;
+}
+
+/* A config entry returned by `AdminClient.describeConfigs()`. */
+export interface ConfigEntryDescription {
+  name: string;
+  /** Empty for sensitive entries. */
+  value: string;
+  /** Where the value comes from, such as `DYNAMIC_TOPIC_CONFIG` or `DEFAULT_CONFIG`. */
+  source: string;
+  isDefault: boolean;
+  isReadOnly: boolean;
+  isSensitive: boolean;
+}
+
+export interface ConfigResourceDescription {
+  type: RESOURCE_TYPE;
+  name: string;
+  /** Sorted by name. */
+  configs: ConfigEntryDescription[];
+  error: any | null;
+}
+
+/* A config change of `AdminClient.incrementalAlterConfigs()`. */
+export interface AlterConfigEntry {
+  configName: string;
+  /** Ignored by `CONFIG_OP_DELETE`. */
+  configValue?: string;
+  operation?: CONFIG_OP;
+}
+
+/* The config changes of a resource. Entries that are not listed keep their value. */
+export interface AlterConfigResource extends ConfigResource {
+  configEntries: AlterConfigEntry[];
+}
+
+export interface CreatePartitionsOptions {
+  /** Have the brokers check the request without adding the partitions. */
+  validateOnly?: boolean;
+}
+
+export interface WaitForPartitionsOptions {
+  /** Defaults to 30 seconds. */
+  timeout?: string | number;
+}
+
+export interface AlterConfigsOptions {
+  /** Have the brokers check the changes without applying them. */
+  validateOnly?: boolean;
+}
+
+/* Allows or denies an operation on resources to a principal. */
+export interface ACLBinding {
+  resourceType: RESOURCE_TYPE;
+  resourceName: string;
+  /** Defaults to `PATTERN_TYPE_LITERAL`. */
+  patternType?: PATTERN_TYPE;
+  /** A user, such as `"User:alice"`. */
+  principal: string;
+  /** The host the principal connects from. Defaults to `"*"`. */
+  host?: string;
+  operation: ACL_OPERATION;
+  permissionType: ACL_PERMISSION;
+}
+
+/* Matches ACL bindings. Fields that are not set match any value. */
+export type ACLBindingFilter = Partial<ACLBinding>;
+
+export interface ConsumerGroupListing {
+  groupId: string;
+  /** The lowercase group state, such as `"stable"` or `"empty"`. */
+  state: string;
+  isSimpleConsumerGroup: boolean;
+}
+
+/* A broker returned by the admin client. */
+export interface Node {
+  id: number;
+  host: string;
+  port: number;
+  /** Empty if the broker has no rack. */
+  rack: string;
+}
+
+export interface DescribeOptions {
+  /** Ask the brokers for the operations the client is allowed to perform. */
+  includeAuthorizedOperations?: boolean;
+}
+
+export interface ClusterDescription {
+  clusterId: string;
+  /** -1 if the cluster has no controller. */
+  controllerId: number;
+  /** Sorted by id. */
+  brokers: Node[];
+  /** Empty unless `includeAuthorizedOperations` is set. */
+  authorizedOperations: ACL_OPERATION[];
+}
+
+export interface TopicPartitionDescription {
+  id: number;
+  /** Null if the partition has no leader. */
+  leader: Node | null;
+  /** The preferred leader comes first. */
+  replicas: Node[];
+  isr: Node[];
+}
+
+export interface TopicDescription {
+  topic: string;
+  /** The base64 encoded topic id. */
+  topicId: string;
+  isInternal: boolean;
+  /** Sorted by id. */
+  partitions: TopicPartitionDescription[];
+  /** Empty unless `includeAuthorizedOperations` is set. */
+  authorizedOperations: ACL_OPERATION[];
+  error: any | null;
+}
+
+export interface ConsumerGroupMember {
+  memberId: string;
+  /** Empty for dynamic members. */
+  groupInstanceId: string;
+  clientId: string;
+  host: string;
+  assignment: TopicPartition[];
+}
+
+export interface ConsumerGroupDescription {
+  groupId: string;
+  state: string;
+  partitionAssignor: string;
+  isSimpleConsumerGroup: boolean;
+  coordinator: Node;
+  members: ConsumerGroupMember[];
+  error: any | null;
+}
+
 /* TopicConfig for creating a new topic. */
 export interface TopicConfig {
   topic: string;
@@ -270,8 +621,43 @@ export interface ProducerStats {
   pending: number;
 }
 
+/* A partition of a topic, with an offset in it. */
+export interface TopicPartition {
+  topic: string;
+  partition: number;
+  offset: number;
+  /** Stored with offsets passed to `commitOffsets()`. */
+  metadata?: string;
+}
+
+/* Passed to `ReaderConfig.onRebalance` when partitions are assigned or revoked. */
+export interface RebalanceEvent {
+  type: "assigned" | "revoked";
+  partitions: TopicPartition[];
+  /** `"cooperative"` rebalances only move some partitions, `"eager"` ones revoke all of them. */
+  protocol: "eager" | "cooperative";
+  /** The partitions were revoked without the group's consent, so their offsets cannot be committed. */
+  lost: boolean;
+  /** Synchronously commit the offsets consumed so far. */
+  commit(): void;
+  /** Start an assigned partition from the given offset. Only allowed on `"assigned"` events. */
+  seek(partition: TopicPartition): void;
+}
+
 export interface ConsumerStats {
   assignments: number;
+  /** The effective isolation level of the consumer. */
+  isolationLevel: ISOLATION_LEVEL;
+  /** Assigned partitions that are paused. */
+  paused: { topic: string; partition: number }[];
+  /** The static member identity, or `""` without `groupInstanceId`. */
+  groupInstanceId: string;
+  /**
+   * The member id assigned by the group coordinator, looked up with a describe
+   * groups request. `""` before joining, or when the lookup fails or several
+   * members match.
+   */
+  memberId: string;
 }
 
 export interface TopicInfo {
@@ -374,9 +760,23 @@ export interface JKS {
  */
 export class Producer {
   constructor(writerConfig: WriterConfig);
-  produce(produceConfig: ProduceConfig): void;
+  /** Returns delivery reports when `produceConfig.deliveryReports` is set. */
+  produce(produceConfig: ProduceConfig): void | DeliveryReport[];
+  /**
+   * Produces messages without blocking the iteration. The promise resolves once
+   * every message is delivered and blocks new calls while `maxInFlight` calls are pending.
+   */
+  produceAsync(produceConfig: ProduceConfig): Promise<void | DeliveryReport[]>;
+  /** Waits for queued messages and settles all pending `produceAsync` promises. */
   flush(): void;
   stats(): ProducerStats;
+  /** Registers `transactionalId` with the cluster. Call once before the first transaction. */
+  initTransactions(): void;
+  beginTransaction(): void;
+  commitTransaction(): void;
+  abortTransaction(): void;
+  /** Adds the consumer's current positions to the open transaction. */
+  sendOffsetsToTransaction(consumer: Consumer): void;
   close(): void;
 }
 
@@ -428,9 +828,46 @@ export class Writer {
 export class Consumer {
   constructor(readerConfig: ReaderConfig);
   consume(consumeConfig: ConsumeConfig): Message[];
+  /**
+   * Poll in the background and call `handler` with each batch on the VU event
+   * loop. The promise settles once `stop()` was called and every batch read
+   * until then was handled, and is rejected when the handler throws.
+   * `consume()` cannot be called meanwhile.
+   */
+  start(handler: (messages: Message[]) => void, streamConfig?: StreamConfig): Promise<void>;
+  /** Stop a started consumer. Returns the promise of `start()`. */
+  stop(): Promise<void> | undefined;
   seek(partition: number, offset: number): void;
   position(partition: number): number;
-  commitOffsets(): void;
+  /**
+   * Move to the first message of `partition` at or after `time`, or to the
+   * end of the partition when there is none.
+   */
+  seekToTimestamp(partition: number, time: Date | string | number): void;
+  /**
+   * Offsets of the first messages at or after `time`, or -1 where there are
+   * none yet. Defaults to the assigned partitions.
+   */
+  offsetsForTimes(time: Date | string | number, partitions?: TopicPartition[]): TopicPartition[];
+  /** Replace the assigned partitions. Only for consumers without `groupId`. */
+  assign(partitions: TopicPartition[]): void;
+  /** Add partitions to the assignment. Only for consumers without `groupId`. */
+  incrementalAssign(partitions: TopicPartition[]): void;
+  /** Remove the given partitions, or all of them, from the assignment. */
+  unassign(partitions?: TopicPartition[]): void;
+  /** Stop fetching from assigned partitions until they are resumed. */
+  pause(partitions: TopicPartition[]): void;
+  resume(partitions: TopicPartition[]): void;
+  /**
+   * Commit the given offsets, or the offsets consumed so far. A committed
+   * offset is the next one to read.
+   */
+  commitOffsets(offsets?: TopicPartition[]): void;
+  /**
+   * Like `commitOffsets()`, without waiting for the result. Results are only
+   * reported by `kafka_reader_commit_count`.
+   */
+  commitOffsetsAsync(offsets?: TopicPartition[]): void;
   stats(): ConsumerStats;
   close(): void;
 }
@@ -485,6 +922,52 @@ export class AdminClient {
   deleteTopic(topic: string): void;
   listTopics(): TopicInfo[];
   getMetadata(topic: string): TopicMetadata;
+  /**
+   * Increase the partition count of a topic to `newTotal`. `assignments` holds
+   * the replica broker ids of each added partition, in order, preferred leader first.
+   */
+  createPartitions(
+    topic: string,
+    newTotal: number,
+    assignments?: number[][] | null,
+    options?: CreatePartitionsOptions,
+  ): void;
+  /** Wait until a topic has at least `count` partitions, all with an elected leader. */
+  waitForPartitions(topic: string, count: number, options?: WaitForPartitionsOptions): TopicMetadata;
+  /** Describe the configs of topics and brokers. */
+  describeConfigs(resources: ConfigResource[]): ConfigResourceDescription[];
+  /** Change config entries of topics and brokers, and throw if any resource fails. */
+  incrementalAlterConfigs(resources: AlterConfigResource[], options?: AlterConfigsOptions): void;
+  /** List the consumer groups of the cluster, sorted by group id. */
+  listConsumerGroups(): ConsumerGroupListing[];
+  /** Describe consumer groups. Groups that fail have `error` set. */
+  describeConsumerGroups(groups: string[]): ConsumerGroupDescription[];
+  /** Delete consumer groups without active members, and throw if any group fails. */
+  deleteConsumerGroups(groups: string[]): void;
+  /**
+   * List the committed offsets of a group, sorted by topic and partition. Without
+   * `partitions`, every partition the group has committed to is returned. Partitions
+   * without a committed offset have an offset of -1.
+   */
+  listConsumerGroupOffsets(groupId: string, partitions?: TopicPartition[]): TopicPartition[];
+  /**
+   * Set the committed offsets of a group without active members. Like a commit, an
+   * offset is the next one the group reads.
+   */
+  alterConsumerGroupOffsets(groupId: string, partitions: TopicPartition[]): void;
+  /** Describe the cluster: its id, controller and brokers. */
+  describeCluster(options?: DescribeOptions): ClusterDescription;
+  /**
+   * Describe topics in the given order. Topics that fail, for example because they
+   * do not exist, have `error` set.
+   */
+  describeTopics(topics: string[], options?: DescribeOptions): TopicDescription[];
+  /** Create ACL bindings, and throw if any binding fails. */
+  createACLs(bindings: ACLBinding[]): void;
+  /** List the ACL bindings matching a filter, or all of them without one. */
+  describeACLs(filter?: ACLBindingFilter): ACLBinding[];
+  /** Delete the ACL bindings matching any of the filters, and return them. */
+  deleteACLs(filters: ACLBindingFilter[]): ACLBinding[];
   close(): void;
 }
 
@@ -80,7 +80,7 @@ func NewAdminClientFromConnectionConfig(connectionConfig *ConnectionConfig) (*Ad
 	}
 
 	doneChan := make(chan struct{})
-	go handleProducerClientEvents(saslContext, pClient, pClient.Events(), doneChan)
+	go handleProducerClientEvents(saslContext, pClient, pClient.Events(), doneChan, nil)
 
 	return &AdminClient{
 		client:   client,
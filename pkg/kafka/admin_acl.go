@@ -0,0 +1,288 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+var (
+	// ACL resource types, besides RESOURCE_TOPIC and RESOURCE_BROKER. ACLs on
+	// the cluster use RESOURCE_BROKER with the name kafka-cluster.
+	resourceGroup = "resource_group"
+	resourceAny   = "resource_any"
+
+	// Resource pattern types.
+	patternTypeLiteral  = "pattern_type_literal"
+	patternTypePrefixed = "pattern_type_prefixed"
+	patternTypeMatch    = "pattern_type_match"
+	patternTypeAny      = "pattern_type_any"
+
+	// ACL operations.
+	aclOperationAny             = "acl_operation_any"
+	aclOperationAll             = "acl_operation_all"
+	aclOperationRead            = "acl_operation_read"
+	aclOperationWrite           = "acl_operation_write"
+	aclOperationCreate          = "acl_operation_create"
+	aclOperationDelete          = "acl_operation_delete"
+	aclOperationAlter           = "acl_operation_alter"
+	aclOperationDescribe        = "acl_operation_describe"
+	aclOperationClusterAction   = "acl_operation_cluster_action"
+	aclOperationDescribeConfigs = "acl_operation_describe_configs"
+	aclOperationAlterConfigs    = "acl_operation_alter_configs"
+	aclOperationIdempotentWrite = "acl_operation_idempotent_write"
+
+	// ACL permission types.
+	aclPermissionAny   = "acl_permission_any"
+	aclPermissionAllow = "acl_permission_allow"
+	aclPermissionDeny  = "acl_permission_deny"
+)
+
+var (
+	aclResourceTypes = map[string]ckafka.ResourceType{
+		resourceTopic:  ckafka.ResourceTopic,
+		resourceGroup:  ckafka.ResourceGroup,
+		resourceBroker: ckafka.ResourceBroker,
+		resourceAny:    ckafka.ResourceAny,
+	}
+	aclPatternTypes = map[string]ckafka.ResourcePatternType{
+		patternTypeLiteral:  ckafka.ResourcePatternTypeLiteral,
+		patternTypePrefixed: ckafka.ResourcePatternTypePrefixed,
+		patternTypeMatch:    ckafka.ResourcePatternTypeMatch,
+		patternTypeAny:      ckafka.ResourcePatternTypeAny,
+	}
+	aclOperations = map[string]ckafka.ACLOperation{
+		aclOperationAny:             ckafka.ACLOperationAny,
+		aclOperationAll:             ckafka.ACLOperationAll,
+		aclOperationRead:            ckafka.ACLOperationRead,
+		aclOperationWrite:           ckafka.ACLOperationWrite,
+		aclOperationCreate:          ckafka.ACLOperationCreate,
+		aclOperationDelete:          ckafka.ACLOperationDelete,
+		aclOperationAlter:           ckafka.ACLOperationAlter,
+		aclOperationDescribe:        ckafka.ACLOperationDescribe,
+		aclOperationClusterAction:   ckafka.ACLOperationClusterAction,
+		aclOperationDescribeConfigs: ckafka.ACLOperationDescribeConfigs,
+		aclOperationAlterConfigs:    ckafka.ACLOperationAlterConfigs,
+		aclOperationIdempotentWrite: ckafka.ACLOperationIdempotentWrite,
+	}
+	aclPermissionTypes = map[string]ckafka.ACLPermissionType{
+		aclPermissionAny:   ckafka.ACLPermissionTypeAny,
+		aclPermissionAllow: ckafka.ACLPermissionTypeAllow,
+		aclPermissionDeny:  ckafka.ACLPermissionTypeDeny,
+	}
+)
+
+// ACLBinding allows or denies an operation on resources to a principal. As a
+// filter of DescribeACLs and DeleteACLs, empty fields match any value, and
+// the *_ANY constants and PATTERN_TYPE_MATCH may be used.
+type ACLBinding struct {
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	// PatternType defaults to PATTERN_TYPE_LITERAL in bindings.
+	PatternType string `json:"patternType"`
+	// Principal is a user, such as User:alice.
+	Principal string `json:"principal"`
+	// Host the principal connects from, which defaults to * in bindings.
+	Host           string `json:"host"`
+	Operation      string `json:"operation"`
+	PermissionType string `json:"permissionType"`
+}
+
+func (a *AdminClient) CreateACLs(ctx context.Context, bindings []ACLBinding) error {
+	if a == nil || a.client == nil {
+		return newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if len(bindings) == 0 {
+		return newInvalidConfigError("ACL bindings", errACLBindingsMustNotBeEmpty)
+	}
+
+	confluentBindings := make(ckafka.ACLBindings, 0, len(bindings))
+	for _, binding := range bindings {
+		confluentBinding, err := aclBindingToConfluent(binding, false)
+		if err != nil {
+			return err
+		}
+		confluentBindings = append(confluentBindings, confluentBinding)
+	}
+
+	results, err := a.client.CreateACLs(ctx, confluentBindings)
+	if err != nil {
+		return NewXk6KafkaError(failedCreateACLs, "Failed to create ACLs.", err)
+	}
+
+	for i, result := range results {
+		if result.Error.Code() != ckafka.ErrNoError {
+			return NewXk6KafkaError(failedCreateACLs, fmt.Sprintf(
+				"Failed to create ACL for %s on %s.", bindings[i].Principal, bindings[i].ResourceName), result.Error)
+		}
+	}
+	return nil
+}
+
+// DescribeACLs returns the ACL bindings that match filter, sorted by
+// resource, principal, host, operation and permission type.
+func (a *AdminClient) DescribeACLs(ctx context.Context, filter ACLBinding) ([]ACLBinding, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+
+	confluentFilter, xk6Err := aclBindingToConfluent(filter, true)
+	if xk6Err != nil {
+		return nil, xk6Err
+	}
+
+	result, err := a.client.DescribeACLs(ctx, confluentFilter)
+	if err == nil {
+		err = normalizeConfluentError(result.Error)
+	}
+	if err != nil {
+		return nil, NewXk6KafkaError(failedDescribeACLs, "Failed to describe ACLs.", err)
+	}
+
+	return aclBindingsFromConfluent(result.ACLBindings), nil
+}
+
+// DeleteACLs deletes the ACL bindings that match any of filters, and returns
+// them.
+func (a *AdminClient) DeleteACLs(ctx context.Context, filters []ACLBinding) ([]ACLBinding, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if len(filters) == 0 {
+		return nil, newInvalidConfigError("ACL binding filters", errACLBindingsMustNotBeEmpty)
+	}
+
+	confluentFilters := make(ckafka.ACLBindingFilters, 0, len(filters))
+	for _, filter := range filters {
+		confluentFilter, err := aclBindingToConfluent(filter, true)
+		if err != nil {
+			return nil, err
+		}
+		confluentFilters = append(confluentFilters, confluentFilter)
+	}
+
+	results, err := a.client.DeleteACLs(ctx, confluentFilters)
+	if err != nil {
+		return nil, NewXk6KafkaError(failedDeleteACLs, "Failed to delete ACLs.", err)
+	}
+
+	var deleted ckafka.ACLBindings
+	for _, result := range results {
+		if result.Error.Code() != ckafka.ErrNoError {
+			return nil, NewXk6KafkaError(failedDeleteACLs, "Failed to delete ACLs.", result.Error)
+		}
+		deleted = append(deleted, result.ACLBindings...)
+	}
+	return aclBindingsFromConfluent(deleted), nil
+}
+
+// aclBindingToConfluent converts a binding, or with filter set a binding
+// filter, which accepts empty and *_ANY values.
+func aclBindingToConfluent(binding ACLBinding, filter bool) (ckafka.ACLBinding, *Xk6KafkaError) {
+	component := "ACL binding"
+	if filter {
+		component = "ACL binding filter"
+	}
+
+	resourceType, ok := aclResourceTypes[binding.ResourceType]
+	if filter && binding.ResourceType == "" {
+		resourceType, ok = ckafka.ResourceAny, true
+	}
+	if !ok {
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errACLResourceTypeInvalid)
+	}
+
+	patternType, ok := aclPatternTypes[binding.PatternType]
+	if binding.PatternType == "" {
+		patternType, ok = ckafka.ResourcePatternTypeLiteral, true
+		if filter {
+			patternType = ckafka.ResourcePatternTypeAny
+		}
+	}
+	if !ok {
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errACLPatternTypeInvalid)
+	}
+
+	operation, ok := aclOperations[binding.Operation]
+	if filter && binding.Operation == "" {
+		operation, ok = ckafka.ACLOperationAny, true
+	}
+	if !ok {
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errACLOperationInvalid)
+	}
+
+	permissionType, ok := aclPermissionTypes[binding.PermissionType]
+	if filter && binding.PermissionType == "" {
+		permissionType, ok = ckafka.ACLPermissionTypeAny, true
+	}
+	if !ok {
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errACLPermissionTypeInvalid)
+	}
+
+	converted := ckafka.ACLBinding{
+		Type:                resourceType,
+		Name:                binding.ResourceName,
+		ResourcePatternType: patternType,
+		Principal:           binding.Principal,
+		Host:                binding.Host,
+		Operation:           operation,
+		PermissionType:      permissionType,
+	}
+	if filter {
+		return converted, nil
+	}
+
+	switch {
+	case resourceType == ckafka.ResourceAny,
+		patternType == ckafka.ResourcePatternTypeAny,
+		patternType == ckafka.ResourcePatternTypeMatch,
+		operation == ckafka.ACLOperationAny,
+		permissionType == ckafka.ACLPermissionTypeAny:
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errACLBindingMatchesAny)
+	case binding.ResourceName == "":
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errResourceNameMustNotBeEmpty)
+	case binding.Principal == "":
+		return ckafka.ACLBinding{}, newInvalidConfigError(component, errACLPrincipalMustNotBeEmpty)
+	}
+	if converted.Host == "" {
+		converted.Host = "*"
+	}
+	return converted, nil
+}
+
+func aclBindingsFromConfluent(bindings ckafka.ACLBindings) []ACLBinding {
+	sort.Sort(bindings)
+
+	converted := make([]ACLBinding, 0, len(bindings))
+	for _, binding := range bindings {
+		converted = append(converted, ACLBinding{
+			ResourceType:   resourceTypeName(binding.Type),
+			ResourceName:   binding.Name,
+			PatternType:    aclConstantName(aclPatternTypes, binding.ResourcePatternType),
+			Principal:      binding.Principal,
+			Host:           binding.Host,
+			Operation:      aclConstantName(aclOperations, binding.Operation),
+			PermissionType: aclConstantName(aclPermissionTypes, binding.PermissionType),
+		})
+	}
+	return converted
+}
+
+// aclConstantName returns the name of the constant of value in names, or the
+// librdkafka name of values xk6-kafka has no constant for.
+func aclConstantName[T interface {
+	comparable
+	String() string
+}](names map[string]T, value T) string {
+	for name, constant := range names {
+		if constant == value {
+			return name
+		}
+	}
+	return value.String()
+}
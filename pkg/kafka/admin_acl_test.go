@@ -0,0 +1,197 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLBindingToConfluent(t *testing.T) {
+	t.Parallel()
+
+	binding, err := aclBindingToConfluent(ACLBinding{
+		ResourceType:   resourceTopic,
+		ResourceName:   "orders",
+		Principal:      "User:alice",
+		Operation:      aclOperationWrite,
+		PermissionType: aclPermissionAllow,
+	}, false)
+	require.Nil(t, err)
+	assert.Equal(t, ckafka.ACLBinding{
+		Type:                ckafka.ResourceTopic,
+		Name:                "orders",
+		ResourcePatternType: ckafka.ResourcePatternTypeLiteral,
+		Principal:           "User:alice",
+		Host:                "*",
+		Operation:           ckafka.ACLOperationWrite,
+		PermissionType:      ckafka.ACLPermissionTypeAllow,
+	}, binding)
+
+	filter, err := aclBindingToConfluent(ACLBinding{Principal: "User:alice"}, true)
+	require.Nil(t, err)
+	assert.Equal(t, ckafka.ACLBindingFilter{
+		Type:                ckafka.ResourceAny,
+		ResourcePatternType: ckafka.ResourcePatternTypeAny,
+		Principal:           "User:alice",
+		Operation:           ckafka.ACLOperationAny,
+		PermissionType:      ckafka.ACLPermissionTypeAny,
+	}, filter)
+
+	valid := ACLBinding{
+		ResourceType:   resourceGroup,
+		ResourceName:   "group-",
+		PatternType:    patternTypePrefixed,
+		Principal:      "User:alice",
+		Operation:      aclOperationRead,
+		PermissionType: aclPermissionDeny,
+	}
+	tests := []struct {
+		name     string
+		modify   func(*ACLBinding)
+		filter   bool
+		expected error
+	}{
+		{"resource type", func(b *ACLBinding) { b.ResourceType = "topic" }, true, errACLResourceTypeInvalid},
+		{"missing resource type", func(b *ACLBinding) { b.ResourceType = "" }, false, errACLResourceTypeInvalid},
+		{"pattern type", func(b *ACLBinding) { b.PatternType = "literal" }, true, errACLPatternTypeInvalid},
+		{"operation", func(b *ACLBinding) { b.Operation = "read" }, true, errACLOperationInvalid},
+		{"missing operation", func(b *ACLBinding) { b.Operation = "" }, false, errACLOperationInvalid},
+		{"permission type", func(b *ACLBinding) { b.PermissionType = "allow" }, true, errACLPermissionTypeInvalid},
+		{"any resource", func(b *ACLBinding) { b.ResourceType = resourceAny }, false, errACLBindingMatchesAny},
+		{"match pattern", func(b *ACLBinding) { b.PatternType = patternTypeMatch }, false, errACLBindingMatchesAny},
+		{"any permission", func(b *ACLBinding) { b.PermissionType = aclPermissionAny }, false, errACLBindingMatchesAny},
+		{"resource name", func(b *ACLBinding) { b.ResourceName = "" }, false, errResourceNameMustNotBeEmpty},
+		{"principal", func(b *ACLBinding) { b.Principal = "" }, false, errACLPrincipalMustNotBeEmpty},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			binding := valid
+			tt.modify(&binding)
+			_, err := aclBindingToConfluent(binding, tt.filter)
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestACLBindingsFromConfluent(t *testing.T) {
+	t.Parallel()
+
+	bindings := aclBindingsFromConfluent(ckafka.ACLBindings{
+		{
+			Type:                ckafka.ResourceTopic,
+			Name:                "payments",
+			ResourcePatternType: ckafka.ResourcePatternTypeLiteral,
+			Principal:           "User:bob",
+			Host:                "*",
+			Operation:           ckafka.ACLOperationRead,
+			PermissionType:      ckafka.ACLPermissionTypeAllow,
+		},
+		{
+			Type:                ckafka.ResourceTopic,
+			Name:                "orders",
+			ResourcePatternType: ckafka.ResourcePatternTypePrefixed,
+			Principal:           "User:alice",
+			Host:                "10.0.0.1",
+			Operation:           ckafka.ACLOperationIdempotentWrite,
+			PermissionType:      ckafka.ACLPermissionTypeDeny,
+		},
+	})
+
+	assert.Equal(t, []ACLBinding{
+		{
+			ResourceType:   resourceTopic,
+			ResourceName:   "orders",
+			PatternType:    patternTypePrefixed,
+			Principal:      "User:alice",
+			Host:           "10.0.0.1",
+			Operation:      aclOperationIdempotentWrite,
+			PermissionType: aclPermissionDeny,
+		},
+		{
+			ResourceType:   resourceTopic,
+			ResourceName:   "payments",
+			PatternType:    patternTypeLiteral,
+			Principal:      "User:bob",
+			Host:           "*",
+			Operation:      aclOperationRead,
+			PermissionType: aclPermissionAllow,
+		},
+	}, bindings)
+}
+
+func TestAdminClientACLsValidateArguments(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	ctx := context.Background()
+	require.ErrorIs(t, admin.CreateACLs(ctx, nil), errACLBindingsMustNotBeEmpty)
+	require.ErrorIs(t, admin.CreateACLs(ctx, []ACLBinding{{ResourceType: resourceTopic}}), errACLOperationInvalid)
+	_, err = admin.DescribeACLs(ctx, ACLBinding{Operation: "write"})
+	require.ErrorIs(t, err, errACLOperationInvalid)
+	_, err = admin.DeleteACLs(ctx, nil)
+	require.ErrorIs(t, err, errACLBindingsMustNotBeEmpty)
+
+	var nilAdmin *AdminClient
+	require.Error(t, nilAdmin.CreateACLs(ctx, nil))
+	_, err = nilAdmin.DescribeACLs(ctx, ACLBinding{})
+	require.Error(t, err)
+	_, err = nilAdmin.DeleteACLs(ctx, []ACLBinding{{}})
+	require.Error(t, err)
+}
+
+func TestAdminClientClassACLs(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	adminClient := test.module.adminClientClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+		})},
+	})
+	require.NotNil(t, adminClient)
+	defer func() {
+		closeAdmin := adminClient.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		closeAdmin(sobek.FunctionCall{})
+	}()
+
+	createACLs := adminClient.Get("createACLs").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		createACLs(sobek.FunctionCall{})
+	}, ErrNotEnoughArguments.Error())
+	requireGoErrorMessage(t, func() {
+		createACLs(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue([]map[string]any{{
+			"resourceType":   resourceAny,
+			"resourceName":   "orders",
+			"principal":      "User:alice",
+			"operation":      aclOperationRead,
+			"permissionType": aclPermissionAllow,
+		}})}})
+	}, "Invalid ACL binding, OriginalError: "+errACLBindingMatchesAny.Error())
+
+	describeACLs := adminClient.Get("describeACLs").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		describeACLs(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"patternType": "prefixed",
+		})}})
+	}, "Invalid ACL binding filter, OriginalError: "+errACLPatternTypeInvalid.Error())
+
+	deleteACLs := adminClient.Get("deleteACLs").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		deleteACLs(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue(map[string]any{})}})
+	}, "Invalid ACL binding filters, OriginalError: expected array, got map[string]interface {}")
+}
@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"context"
+	"sort"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// noController is the controller id of a cluster without a controller.
+const noController = -1
+
+type DescribeOptions struct {
+	// IncludeAuthorizedOperations asks the brokers for the operations the
+	// client is allowed to perform.
+	IncludeAuthorizedOperations bool `json:"includeAuthorizedOperations"`
+}
+
+type ClusterDescription struct {
+	ClusterID    string
+	ControllerID int
+	// Brokers are sorted by id.
+	Brokers []Node
+	// AuthorizedOperations holds ACL_OPERATION_* constants, and is nil unless
+	// requested.
+	AuthorizedOperations []string
+}
+
+type TopicPartitionDescription struct {
+	ID int
+	// Leader is nil if the partition has no leader.
+	Leader   *Node
+	Replicas []Node
+	Isr      []Node
+}
+
+type TopicDescription struct {
+	Topic string
+	// TopicID is the base64 encoded topic id.
+	TopicID              string
+	IsInternal           bool
+	Partitions           []TopicPartitionDescription
+	AuthorizedOperations []string
+	Error                error
+}
+
+func (a *AdminClient) DescribeCluster(ctx context.Context, options DescribeOptions) (*ClusterDescription, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+
+	result, err := a.client.DescribeCluster(
+		ctx, ckafka.SetAdminOptionIncludeAuthorizedOperations(options.IncludeAuthorizedOperations),
+	)
+	if err != nil {
+		return nil, NewXk6KafkaError(failedDescribeCluster, "Failed to describe cluster.", err)
+	}
+
+	return clusterDescriptionFromConfluent(result), nil
+}
+
+// DescribeTopics describes topics in the order they are given. Topics that
+// fail, for example because they do not exist, have Error set.
+func (a *AdminClient) DescribeTopics(
+	ctx context.Context,
+	topics []string,
+	options DescribeOptions,
+) ([]TopicDescription, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if len(topics) == 0 {
+		return nil, newInvalidConfigError("topics", errTopicsMustNotBeEmpty)
+	}
+	for _, topic := range topics {
+		if topic == "" {
+			return nil, newInvalidConfigError("topics", errTopicMustNotBeEmpty)
+		}
+	}
+
+	result, err := a.client.DescribeTopics(
+		ctx,
+		ckafka.NewTopicCollectionOfTopicNames(topics),
+		ckafka.SetAdminOptionIncludeAuthorizedOperations(options.IncludeAuthorizedOperations),
+	)
+	if err != nil {
+		return nil, NewXk6KafkaError(failedDescribeTopics, "Failed to describe topics.", err)
+	}
+
+	descriptions := make([]TopicDescription, 0, len(result.TopicDescriptions))
+	for _, description := range result.TopicDescriptions {
+		descriptions = append(descriptions, topicDescriptionFromConfluent(description))
+	}
+	return descriptions, nil
+}
+
+func clusterDescriptionFromConfluent(result ckafka.DescribeClusterResult) *ClusterDescription {
+	description := &ClusterDescription{
+		ControllerID:         noController,
+		Brokers:              nodesFromConfluent(result.Nodes),
+		AuthorizedOperations: aclOperationNames(result.AuthorizedOperations),
+	}
+	if result.ClusterID != nil {
+		description.ClusterID = *result.ClusterID
+	}
+	if result.Controller != nil {
+		description.ControllerID = result.Controller.ID
+	}
+	sort.Slice(description.Brokers, func(i, j int) bool {
+		return description.Brokers[i].ID < description.Brokers[j].ID
+	})
+
+	return description
+}
+
+func topicDescriptionFromConfluent(description ckafka.TopicDescription) TopicDescription {
+	converted := TopicDescription{
+		Topic:                description.Name,
+		TopicID:              description.TopicID.String(),
+		IsInternal:           description.IsInternal,
+		Partitions:           make([]TopicPartitionDescription, 0, len(description.Partitions)),
+		AuthorizedOperations: aclOperationNames(description.AuthorizedOperations),
+		Error:                normalizeConfluentError(description.Error),
+	}
+	for _, partition := range description.Partitions {
+		partitionDescription := TopicPartitionDescription{
+			ID:       partition.Partition,
+			Replicas: nodesFromConfluent(partition.Replicas),
+			Isr:      nodesFromConfluent(partition.Isr),
+		}
+		if partition.Leader != nil {
+			leader := nodeFromConfluent(*partition.Leader)
+			partitionDescription.Leader = &leader
+		}
+		converted.Partitions = append(converted.Partitions, partitionDescription)
+	}
+	sort.Slice(converted.Partitions, func(i, j int) bool {
+		return converted.Partitions[i].ID < converted.Partitions[j].ID
+	})
+
+	return converted
+}
+
+func nodesFromConfluent(nodes []ckafka.Node) []Node {
+	converted := make([]Node, 0, len(nodes))
+	for _, node := range nodes {
+		converted = append(converted, nodeFromConfluent(node))
+	}
+	return converted
+}
+
+// aclOperationNames returns the ACL_OPERATION_* constants of operations, or
+// nil if the brokers did not return them.
+func aclOperationNames(operations []ckafka.ACLOperation) []string {
+	if operations == nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		names = append(names, aclConstantName(aclOperations, operation))
+	}
+	sort.Strings(names)
+	return names
+}
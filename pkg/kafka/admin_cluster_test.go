@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterDescriptionFromConfluent(t *testing.T) {
+	t.Parallel()
+
+	clusterID := "cluster-1"
+	rack := "rack-b"
+	description := clusterDescriptionFromConfluent(ckafka.DescribeClusterResult{
+		ClusterID:  &clusterID,
+		Controller: &ckafka.Node{ID: 2, Host: "broker-2", Port: 9092},
+		Nodes: []ckafka.Node{
+			{ID: 2, Host: "broker-2", Port: 9092, Rack: &rack},
+			{ID: 1, Host: "broker-1", Port: 9092},
+		},
+		AuthorizedOperations: []ckafka.ACLOperation{ckafka.ACLOperationDescribe, ckafka.ACLOperationAlter},
+	})
+
+	assert.Equal(t, &ClusterDescription{
+		ClusterID:    "cluster-1",
+		ControllerID: 2,
+		Brokers: []Node{
+			{ID: 1, Host: "broker-1", Port: 9092},
+			{ID: 2, Host: "broker-2", Port: 9092, Rack: "rack-b"},
+		},
+		AuthorizedOperations: []string{aclOperationAlter, aclOperationDescribe},
+	}, description)
+
+	empty := clusterDescriptionFromConfluent(ckafka.DescribeClusterResult{})
+	assert.Equal(t, noController, empty.ControllerID)
+	assert.Empty(t, empty.ClusterID)
+	assert.Nil(t, empty.AuthorizedOperations)
+}
+
+func TestTopicDescriptionFromConfluent(t *testing.T) {
+	t.Parallel()
+
+	broker1 := ckafka.Node{ID: 1, Host: "broker-1", Port: 9092}
+	broker2 := ckafka.Node{ID: 2, Host: "broker-2", Port: 9092}
+	description := topicDescriptionFromConfluent(ckafka.TopicDescription{
+		Name: "orders",
+		Partitions: []ckafka.TopicPartitionInfo{
+			{Partition: 1, Replicas: []ckafka.Node{broker2, broker1}, Isr: []ckafka.Node{broker2}},
+			{Partition: 0, Leader: &broker1, Replicas: []ckafka.Node{broker1, broker2}, Isr: []ckafka.Node{broker1, broker2}},
+		},
+		AuthorizedOperations: []ckafka.ACLOperation{ckafka.ACLOperationRead},
+	})
+
+	assert.Equal(t, "orders", description.Topic)
+	require.NoError(t, description.Error)
+	assert.Equal(t, []string{aclOperationRead}, description.AuthorizedOperations)
+	require.Len(t, description.Partitions, 2)
+	assert.Equal(t, 0, description.Partitions[0].ID)
+	assert.Equal(t, &Node{ID: 1, Host: "broker-1", Port: 9092}, description.Partitions[0].Leader)
+	assert.Nil(t, description.Partitions[1].Leader)
+	assert.Equal(t, []Node{
+		{ID: 2, Host: "broker-2", Port: 9092},
+		{ID: 1, Host: "broker-1", Port: 9092},
+	}, description.Partitions[1].Replicas)
+	assert.Len(t, description.Partitions[1].Isr, 1)
+
+	failed := topicDescriptionFromConfluent(ckafka.TopicDescription{
+		Name:  "missing",
+		Error: ckafka.NewError(ckafka.ErrUnknownTopicOrPart, "unknown topic", false),
+	})
+	require.Error(t, failed.Error)
+	assert.Empty(t, failed.Partitions)
+}
+
+func TestAdminClientDescribeTopicsValidatesTopics(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	ctx := context.Background()
+	_, err = admin.DescribeTopics(ctx, nil, DescribeOptions{})
+	require.ErrorIs(t, err, errTopicsMustNotBeEmpty)
+	_, err = admin.DescribeTopics(ctx, []string{"orders", ""}, DescribeOptions{})
+	require.ErrorIs(t, err, errTopicMustNotBeEmpty)
+
+	var nilAdmin *AdminClient
+	_, err = nilAdmin.DescribeCluster(ctx, DescribeOptions{})
+	require.Error(t, err)
+	_, err = nilAdmin.DescribeTopics(ctx, []string{"orders"}, DescribeOptions{})
+	require.Error(t, err)
+}
+
+func TestAdminClientClassDescribeTopics(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	adminClient := test.module.adminClientClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+		})},
+	})
+	require.NotNil(t, adminClient)
+	defer func() {
+		closeAdmin := adminClient.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		closeAdmin(sobek.FunctionCall{})
+	}()
+
+	describeTopics := adminClient.Get("describeTopics").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		describeTopics(sobek.FunctionCall{})
+	}, ErrNotEnoughArguments.Error())
+	requireGoErrorMessage(t, func() {
+		describeTopics(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue("orders")}})
+	}, "Invalid topics, OriginalError: expected array, got string")
+
+	describeCluster := adminClient.Get("describeCluster").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		describeCluster(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue("all")}})
+	}, "Invalid describe options, OriginalError: expected object, got string")
+}
+
+func TestTopicDescriptionsToJS(t *testing.T) {
+	t.Parallel()
+
+	converted := topicDescriptionsToJS([]TopicDescription{{
+		Topic:      "orders",
+		Partitions: []TopicPartitionDescription{{ID: 0}},
+	}})
+	require.Len(t, converted, 1)
+	partitions := converted[0]["partitions"].([]map[string]any)
+	assert.Nil(t, partitions[0]["leader"])
+}
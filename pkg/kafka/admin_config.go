@@ -0,0 +1,221 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+var (
+	// Resource types.
+	resourceTopic  = "resource_topic"
+	resourceBroker = "resource_broker"
+
+	// Incremental config operations.
+	configOpSet      = "config_op_set"
+	configOpDelete   = "config_op_delete"
+	configOpAppend   = "config_op_append"
+	configOpSubtract = "config_op_subtract"
+)
+
+// ConfigResource is a topic, or a broker named by its id.
+type ConfigResource struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// ConfigEntryDescription is a config entry returned by DescribeConfigs.
+type ConfigEntryDescription struct {
+	Name  string
+	Value string
+	// Source is the librdkafka name of where the value comes from, such as
+	// DYNAMIC_TOPIC_CONFIG or DEFAULT_CONFIG.
+	Source      string
+	IsDefault   bool
+	IsReadOnly  bool
+	IsSensitive bool
+}
+
+type ConfigResourceDescription struct {
+	Type    string
+	Name    string
+	Configs []ConfigEntryDescription
+	Error   error
+}
+
+// AlterConfigResource lists the config entries to change on a resource.
+// Entries that are not listed keep their value.
+type AlterConfigResource struct {
+	Type          string             `json:"type"`
+	Name          string             `json:"name"`
+	ConfigEntries []AlterConfigEntry `json:"configEntries"`
+}
+
+type AlterConfigEntry struct {
+	ConfigName  string `json:"configName"`
+	ConfigValue string `json:"configValue"`
+	// Operation is one of the CONFIG_OP_* constants and defaults to
+	// CONFIG_OP_SET.
+	Operation string `json:"operation"`
+}
+
+type AlterConfigsOptions struct {
+	// ValidateOnly makes the brokers check the changes without applying them.
+	ValidateOnly bool `json:"validateOnly"`
+}
+
+func (a *AdminClient) DescribeConfigs(
+	ctx context.Context,
+	resources []ConfigResource,
+) ([]ConfigResourceDescription, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if len(resources) == 0 {
+		return nil, newInvalidConfigError("config resources", errConfigResourcesMustNotBeEmpty)
+	}
+
+	confluentResources := make([]ckafka.ConfigResource, 0, len(resources))
+	for _, resource := range resources {
+		confluentResource, err := configResourceToConfluent(resource.Type, resource.Name)
+		if err != nil {
+			return nil, err
+		}
+		confluentResources = append(confluentResources, confluentResource)
+	}
+
+	results, err := a.client.DescribeConfigs(ctx, confluentResources)
+	if err != nil {
+		return nil, NewXk6KafkaError(failedDescribeConfigs, "Failed to describe configs.", err)
+	}
+
+	descriptions := make([]ConfigResourceDescription, 0, len(results))
+	for _, result := range results {
+		descriptions = append(descriptions, configResourceResultToDescription(result))
+	}
+	return descriptions, nil
+}
+
+func (a *AdminClient) IncrementalAlterConfigs(
+	ctx context.Context,
+	resources []AlterConfigResource,
+	options AlterConfigsOptions,
+) error {
+	if a == nil || a.client == nil {
+		return newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if len(resources) == 0 {
+		return newInvalidConfigError("config resources", errConfigResourcesMustNotBeEmpty)
+	}
+
+	confluentResources := make([]ckafka.ConfigResource, 0, len(resources))
+	for _, resource := range resources {
+		confluentResource, err := configResourceToConfluent(resource.Type, resource.Name)
+		if err != nil {
+			return err
+		}
+		for _, entry := range resource.ConfigEntries {
+			operation, err := alterConfigOpToConfluent(entry.Operation)
+			if err != nil {
+				return err
+			}
+			confluentResource.Config = append(confluentResource.Config, ckafka.ConfigEntry{
+				Name:                 entry.ConfigName,
+				Value:                entry.ConfigValue,
+				IncrementalOperation: operation,
+			})
+		}
+		confluentResources = append(confluentResources, confluentResource)
+	}
+
+	results, err := a.client.IncrementalAlterConfigs(
+		ctx, confluentResources, ckafka.SetAdminValidateOnly(options.ValidateOnly),
+	)
+	if err != nil {
+		return NewXk6KafkaError(failedAlterConfigs, "Failed to alter configs.", err)
+	}
+
+	for _, result := range results {
+		if result.Error.Code() != ckafka.ErrNoError {
+			resourceType := strings.ToLower(result.Type.String())
+			return NewXk6KafkaError(failedAlterConfigs, fmt.Sprintf(
+				"Failed to alter configs of %s %s.", resourceType, result.Name), result.Error)
+		}
+	}
+	return nil
+}
+
+func configResourceToConfluent(resourceType, name string) (ckafka.ConfigResource, *Xk6KafkaError) {
+	if name == "" {
+		return ckafka.ConfigResource{}, newInvalidConfigError("config resource", errResourceNameMustNotBeEmpty)
+	}
+
+	switch resourceType {
+	case resourceTopic:
+		return ckafka.ConfigResource{Type: ckafka.ResourceTopic, Name: name}, nil
+	case resourceBroker:
+		return ckafka.ConfigResource{Type: ckafka.ResourceBroker, Name: name}, nil
+	default:
+		return ckafka.ConfigResource{}, newInvalidConfigError("config resource", errConfigResourceTypeInvalid)
+	}
+}
+
+func alterConfigOpToConfluent(operation string) (ckafka.AlterConfigOpType, *Xk6KafkaError) {
+	switch operation {
+	case "", configOpSet:
+		return ckafka.AlterConfigOpTypeSet, nil
+	case configOpDelete:
+		return ckafka.AlterConfigOpTypeDelete, nil
+	case configOpAppend:
+		return ckafka.AlterConfigOpTypeAppend, nil
+	case configOpSubtract:
+		return ckafka.AlterConfigOpTypeSubtract, nil
+	default:
+		return 0, newInvalidConfigError("config entry", errConfigOperationInvalid)
+	}
+}
+
+// resourceTypeName returns the RESOURCE_* constant of a resource type.
+func resourceTypeName(resourceType ckafka.ResourceType) string {
+	switch resourceType {
+	case ckafka.ResourceTopic:
+		return resourceTopic
+	case ckafka.ResourceBroker:
+		return resourceBroker
+	case ckafka.ResourceGroup:
+		return resourceGroup
+	case ckafka.ResourceAny:
+		return resourceAny
+	default:
+		return resourceType.String()
+	}
+}
+
+func configResourceResultToDescription(result ckafka.ConfigResourceResult) ConfigResourceDescription {
+	description := ConfigResourceDescription{
+		Type:    resourceTypeName(result.Type),
+		Name:    result.Name,
+		Configs: make([]ConfigEntryDescription, 0, len(result.Config)),
+		Error:   normalizeConfluentError(result.Error),
+	}
+	for _, entry := range result.Config {
+		description.Configs = append(description.Configs, ConfigEntryDescription{
+			Name:        entry.Name,
+			Value:       entry.Value,
+			Source:      entry.Source.String(),
+			IsDefault:   entry.IsDefault,
+			IsReadOnly:  entry.IsReadOnly,
+			IsSensitive: entry.IsSensitive,
+		})
+	}
+	sort.Slice(description.Configs, func(i, j int) bool {
+		return description.Configs[i].Name < description.Configs[j].Name
+	})
+
+	return description
+}
@@ -0,0 +1,149 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminClientConfigsValidateResources(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	ctx := context.Background()
+	_, err = admin.DescribeConfigs(ctx, nil)
+	require.ErrorIs(t, err, errConfigResourcesMustNotBeEmpty)
+	_, err = admin.DescribeConfigs(ctx, []ConfigResource{{Type: resourceTopic}})
+	require.ErrorIs(t, err, errResourceNameMustNotBeEmpty)
+	_, err = admin.DescribeConfigs(ctx, []ConfigResource{{Type: "resource_group", Name: "g"}})
+	require.ErrorIs(t, err, errConfigResourceTypeInvalid)
+
+	err = admin.IncrementalAlterConfigs(ctx, nil, AlterConfigsOptions{})
+	require.ErrorIs(t, err, errConfigResourcesMustNotBeEmpty)
+	err = admin.IncrementalAlterConfigs(ctx, []AlterConfigResource{{
+		Type:          resourceTopic,
+		Name:          "topic",
+		ConfigEntries: []AlterConfigEntry{{ConfigName: "retention.ms", ConfigValue: "1000", Operation: "replace"}},
+	}}, AlterConfigsOptions{})
+	require.ErrorIs(t, err, errConfigOperationInvalid)
+
+	var nilAdmin *AdminClient
+	_, err = nilAdmin.DescribeConfigs(ctx, []ConfigResource{{Type: resourceTopic, Name: "topic"}})
+	require.Error(t, err)
+	require.Error(t, nilAdmin.IncrementalAlterConfigs(ctx, nil, AlterConfigsOptions{}))
+}
+
+func TestAlterConfigOpToConfluent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]ckafka.AlterConfigOpType{
+		"":               ckafka.AlterConfigOpTypeSet,
+		configOpSet:      ckafka.AlterConfigOpTypeSet,
+		configOpDelete:   ckafka.AlterConfigOpTypeDelete,
+		configOpAppend:   ckafka.AlterConfigOpTypeAppend,
+		configOpSubtract: ckafka.AlterConfigOpTypeSubtract,
+	}
+	for operation, expected := range tests {
+		converted, err := alterConfigOpToConfluent(operation)
+		require.Nil(t, err)
+		assert.Equal(t, expected, converted)
+	}
+}
+
+func TestConfigResourceResultToDescription(t *testing.T) {
+	t.Parallel()
+
+	description := configResourceResultToDescription(ckafka.ConfigResourceResult{
+		Type: ckafka.ResourceTopic,
+		Name: "topic",
+		Config: map[string]ckafka.ConfigEntryResult{
+			"retention.ms": {
+				Name:   "retention.ms",
+				Value:  "1000",
+				Source: ckafka.ConfigSourceDynamicTopic,
+			},
+			"cleanup.policy": {
+				Name:      "cleanup.policy",
+				Value:     "delete",
+				Source:    ckafka.ConfigSourceDefault,
+				IsDefault: true,
+			},
+			"sasl.jaas.config": {
+				Name:        "sasl.jaas.config",
+				Source:      ckafka.ConfigSourceStaticBroker,
+				IsReadOnly:  true,
+				IsSensitive: true,
+			},
+		},
+	})
+
+	assert.Equal(t, ConfigResourceDescription{
+		Type: resourceTopic,
+		Name: "topic",
+		Configs: []ConfigEntryDescription{
+			{Name: "cleanup.policy", Value: "delete", Source: "DEFAULT_CONFIG", IsDefault: true},
+			{Name: "retention.ms", Value: "1000", Source: "DYNAMIC_TOPIC_CONFIG"},
+			{Name: "sasl.jaas.config", Source: "STATIC_BROKER_CONFIG", IsReadOnly: true, IsSensitive: true},
+		},
+	}, description)
+
+	failed := configResourceResultToDescription(ckafka.ConfigResourceResult{
+		Type:  ckafka.ResourceBroker,
+		Name:  "1",
+		Error: ckafka.NewError(ckafka.ErrUnknownTopicOrPart, "unknown", false),
+	})
+	assert.Equal(t, resourceBroker, failed.Type)
+	assert.Error(t, failed.Error)
+	assert.Empty(t, failed.Configs)
+}
+
+func TestAdminClientClassConfigsValidateArguments(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	adminClient := test.module.adminClientClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+		})},
+	})
+	require.NotNil(t, adminClient)
+	defer func() {
+		closeAdmin := adminClient.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		closeAdmin(sobek.FunctionCall{})
+	}()
+
+	describeConfigs := adminClient.Get("describeConfigs").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		describeConfigs(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue("topic")}})
+	}, "Invalid config resources, OriginalError: expected array, got string")
+	requireGoErrorMessage(t, func() {
+		describeConfigs(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue([]any{
+			map[string]any{"type": "topic", "name": "topic"},
+		})}})
+	}, "Invalid config resource, OriginalError: type must be RESOURCE_TOPIC or RESOURCE_BROKER")
+
+	alterConfigs := adminClient.Get("incrementalAlterConfigs").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		alterConfigs(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue([]any{
+			map[string]any{
+				"type":          resourceTopic,
+				"name":          "topic",
+				"configEntries": []any{map[string]any{"configName": "retention.ms", "operation": "replace"}},
+			},
+		})}})
+	}, "Invalid config entry, OriginalError: operation must be a CONFIG_OP_* constant")
+}
@@ -0,0 +1,291 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// noCommittedOffset is the offset ListConsumerGroupOffsets returns for
+// partitions the group has not committed to.
+const noCommittedOffset = -1
+
+type ConsumerGroupListing struct {
+	GroupID string
+	// State is the lowercase group state, such as stable or empty.
+	State                 string
+	IsSimpleConsumerGroup bool
+}
+
+// Node is a broker, as returned by the admin client.
+type Node struct {
+	ID   int
+	Host string
+	Port int
+	Rack string
+}
+
+type ConsumerGroupMember struct {
+	MemberID        string
+	GroupInstanceID string
+	ClientID        string
+	Host            string
+	Assignment      []TopicPartition
+}
+
+type ConsumerGroupDescription struct {
+	GroupID               string
+	State                 string
+	PartitionAssignor     string
+	IsSimpleConsumerGroup bool
+	Coordinator           Node
+	Members               []ConsumerGroupMember
+	Error                 error
+}
+
+// ListConsumerGroups returns the groups known to the cluster, sorted by id.
+func (a *AdminClient) ListConsumerGroups(ctx context.Context) ([]ConsumerGroupListing, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+
+	result, err := a.client.ListConsumerGroups(ctx)
+	if err == nil {
+		err = errors.Join(result.Errors...)
+	}
+	if err != nil {
+		return nil, NewXk6KafkaError(failedListGroups, "Failed to list consumer groups.", err)
+	}
+
+	listings := make([]ConsumerGroupListing, 0, len(result.Valid))
+	for _, listing := range result.Valid {
+		listings = append(listings, ConsumerGroupListing{
+			GroupID:               listing.GroupID,
+			State:                 consumerGroupStateName(listing.State),
+			IsSimpleConsumerGroup: listing.IsSimpleConsumerGroup,
+		})
+	}
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].GroupID < listings[j].GroupID
+	})
+	return listings, nil
+}
+
+func (a *AdminClient) DescribeConsumerGroups(
+	ctx context.Context,
+	groups []string,
+) ([]ConsumerGroupDescription, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if err := validateGroupIDs(groups); err != nil {
+		return nil, err
+	}
+
+	result, err := a.client.DescribeConsumerGroups(ctx, groups)
+	if err != nil {
+		return nil, NewXk6KafkaError(failedDescribeGroups, "Failed to describe consumer groups.", err)
+	}
+
+	descriptions := make([]ConsumerGroupDescription, 0, len(result.ConsumerGroupDescriptions))
+	for _, description := range result.ConsumerGroupDescriptions {
+		descriptions = append(descriptions, consumerGroupDescriptionFromConfluent(description))
+	}
+	return descriptions, nil
+}
+
+// DeleteConsumerGroups deletes groups that have no active members.
+func (a *AdminClient) DeleteConsumerGroups(ctx context.Context, groups []string) error {
+	if a == nil || a.client == nil {
+		return newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if err := validateGroupIDs(groups); err != nil {
+		return err
+	}
+
+	result, err := a.client.DeleteConsumerGroups(ctx, groups)
+	if err != nil {
+		return NewXk6KafkaError(failedDeleteGroups, "Failed to delete consumer groups.", err)
+	}
+
+	for _, group := range result.ConsumerGroupResults {
+		if group.Error.Code() != ckafka.ErrNoError {
+			return NewXk6KafkaError(failedDeleteGroups, fmt.Sprintf(
+				"Failed to delete consumer group %s.", group.Group), group.Error)
+		}
+	}
+	return nil
+}
+
+// ListConsumerGroupOffsets returns the committed offsets of group, sorted by
+// topic and partition. Without partitions, every partition the group has
+// committed to is returned. Partitions without a committed offset have an
+// offset of -1.
+func (a *AdminClient) ListConsumerGroupOffsets(
+	ctx context.Context,
+	group string,
+	partitions []TopicPartition,
+) ([]TopicPartition, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if group == "" {
+		return nil, newInvalidConfigError("consumer group", errGroupIDMustNotBeEmpty)
+	}
+
+	var confluentPartitions []ckafka.TopicPartition
+	for _, partition := range partitions {
+		if partition.Topic == "" {
+			return nil, newInvalidConfigError("consumer group offsets", errTopicMustNotBeEmpty)
+		}
+		partitionValue, err := consumerPartition(partition.Partition, "consumer group offsets")
+		if err != nil {
+			return nil, err
+		}
+		topic := partition.Topic
+		confluentPartitions = append(confluentPartitions, ckafka.TopicPartition{
+			Topic:     &topic,
+			Partition: partitionValue,
+		})
+	}
+
+	result, err := a.client.ListConsumerGroupOffsets(ctx, []ckafka.ConsumerGroupTopicPartitions{{
+		Group:      group,
+		Partitions: confluentPartitions,
+	}}, ckafka.SetAdminRequireStableOffsets(true))
+	if err != nil {
+		return nil, NewXk6KafkaError(failedListGroupOffsets, "Failed to list consumer group offsets.", err)
+	}
+
+	return groupOffsetsFromConfluent(result.ConsumerGroupsTopicPartitions, failedListGroupOffsets)
+}
+
+// AlterConsumerGroupOffsets sets the committed offsets of group. Like a
+// commit, an offset is the next one the group reads. The group must have no
+// active members.
+func (a *AdminClient) AlterConsumerGroupOffsets(
+	ctx context.Context,
+	group string,
+	partitions []TopicPartition,
+) error {
+	if a == nil || a.client == nil {
+		return newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if group == "" {
+		return newInvalidConfigError("consumer group", errGroupIDMustNotBeEmpty)
+	}
+	if len(partitions) == 0 {
+		return newInvalidConfigError("consumer group offsets", errGroupOffsetsMustNotBeEmpty)
+	}
+	offsets, err := commitTopicPartitions(partitions, "consumer group offsets")
+	if err != nil {
+		return err
+	}
+
+	result, err := a.client.AlterConsumerGroupOffsets(ctx, []ckafka.ConsumerGroupTopicPartitions{{
+		Group:      group,
+		Partitions: offsets,
+	}})
+	if err != nil {
+		return NewXk6KafkaError(failedAlterGroupOffsets, "Failed to alter consumer group offsets.", err)
+	}
+
+	_, err = groupOffsetsFromConfluent(result.ConsumerGroupsTopicPartitions, failedAlterGroupOffsets)
+	return err
+}
+
+func validateGroupIDs(groups []string) *Xk6KafkaError {
+	if len(groups) == 0 {
+		return newInvalidConfigError("consumer groups", errGroupsMustNotBeEmpty)
+	}
+	for _, group := range groups {
+		if group == "" {
+			return newInvalidConfigError("consumer groups", errGroupIDMustNotBeEmpty)
+		}
+	}
+	return nil
+}
+
+// consumerGroupStateName returns the lowercase name of a group state, which
+// librdkafka spells like PreparingRebalance.
+func consumerGroupStateName(state ckafka.ConsumerGroupState) string {
+	return strings.ToLower(state.String())
+}
+
+func nodeFromConfluent(node ckafka.Node) Node {
+	converted := Node{ID: node.ID, Host: node.Host, Port: node.Port}
+	if node.Rack != nil {
+		converted.Rack = *node.Rack
+	}
+	return converted
+}
+
+func consumerGroupDescriptionFromConfluent(description ckafka.ConsumerGroupDescription) ConsumerGroupDescription {
+	converted := ConsumerGroupDescription{
+		GroupID:               description.GroupID,
+		State:                 consumerGroupStateName(description.State),
+		PartitionAssignor:     description.PartitionAssignor,
+		IsSimpleConsumerGroup: description.IsSimpleConsumerGroup,
+		Coordinator:           nodeFromConfluent(description.Coordinator),
+		Members:               make([]ConsumerGroupMember, 0, len(description.Members)),
+		Error:                 normalizeConfluentError(description.Error),
+	}
+	for _, member := range description.Members {
+		converted.Members = append(converted.Members, ConsumerGroupMember{
+			MemberID:        member.ConsumerID,
+			GroupInstanceID: member.GroupInstanceID,
+			ClientID:        member.ClientID,
+			Host:            member.Host,
+			Assignment:      topicPartitionsFromConfluent(member.Assignment.TopicPartitions),
+		})
+	}
+	return converted
+}
+
+// groupOffsetsFromConfluent converts the offsets of the single group in
+// results, and fails on the first partition with an error.
+func groupOffsetsFromConfluent(
+	results []ckafka.ConsumerGroupTopicPartitions,
+	code errCode,
+) ([]TopicPartition, error) {
+	var offsets []TopicPartition
+	for _, result := range results {
+		for _, partition := range result.Partitions {
+			offset := TopicPartition{
+				Partition: int(partition.Partition),
+				Offset:    int64(partition.Offset),
+			}
+			if partition.Topic != nil {
+				offset.Topic = *partition.Topic
+			}
+			if partition.Error != nil {
+				return nil, NewXk6KafkaError(code, fmt.Sprintf(
+					"Offset of %s [%d] failed for consumer group %s.",
+					offset.Topic, offset.Partition, result.Group), partition.Error)
+			}
+			if partition.Offset < 0 {
+				offset.Offset = noCommittedOffset
+			}
+			if partition.Metadata != nil {
+				offset.Metadata = *partition.Metadata
+			}
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool {
+		if offsets[i].Topic != offsets[j].Topic {
+			return offsets[i].Topic < offsets[j].Topic
+		}
+		return offsets[i].Partition < offsets[j].Partition
+	})
+	return offsets, nil
+}
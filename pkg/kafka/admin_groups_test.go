@@ -0,0 +1,197 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminClientConsumerGroupsValidateArguments(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	ctx := context.Background()
+	_, err = admin.DescribeConsumerGroups(ctx, nil)
+	require.ErrorIs(t, err, errGroupsMustNotBeEmpty)
+	require.ErrorIs(t, admin.DeleteConsumerGroups(ctx, []string{"group", ""}), errGroupIDMustNotBeEmpty)
+	_, err = admin.ListConsumerGroupOffsets(ctx, "", nil)
+	require.ErrorIs(t, err, errGroupIDMustNotBeEmpty)
+	_, err = admin.ListConsumerGroupOffsets(ctx, "group", []TopicPartition{{Partition: 1}})
+	require.ErrorIs(t, err, errTopicMustNotBeEmpty)
+	require.ErrorIs(t, admin.AlterConsumerGroupOffsets(ctx, "group", nil), errGroupOffsetsMustNotBeEmpty)
+	err = admin.AlterConsumerGroupOffsets(ctx, "group", []TopicPartition{{Topic: "topic", Offset: -1}})
+	require.ErrorIs(t, err, errCommitOffsetNegative)
+	assert.Contains(t, err.Error(), "Invalid consumer group offsets")
+
+	var nilAdmin *AdminClient
+	_, err = nilAdmin.ListConsumerGroups(ctx)
+	require.Error(t, err)
+	_, err = nilAdmin.DescribeConsumerGroups(ctx, []string{"group"})
+	require.Error(t, err)
+	require.Error(t, nilAdmin.DeleteConsumerGroups(ctx, []string{"group"}))
+	_, err = nilAdmin.ListConsumerGroupOffsets(ctx, "group", nil)
+	require.Error(t, err)
+	require.Error(t, nilAdmin.AlterConsumerGroupOffsets(ctx, "group", []TopicPartition{{Topic: "topic"}}))
+}
+
+func TestAdminClientConsumerGroupOffsets(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+	require.NoError(t, mockCluster.CreateTopic("group-offsets-topic", 2, 1))
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, admin.AlterConsumerGroupOffsets(ctx, "group-offsets", []TopicPartition{
+		{Topic: "group-offsets-topic", Partition: 1, Offset: 5, Metadata: "reset"},
+	}))
+
+	offsets, err := admin.ListConsumerGroupOffsets(ctx, "group-offsets", []TopicPartition{
+		{Topic: "group-offsets-topic", Partition: 1},
+		{Topic: "group-offsets-topic", Partition: 0},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []TopicPartition{
+		{Topic: "group-offsets-topic", Partition: 0, Offset: noCommittedOffset},
+		{Topic: "group-offsets-topic", Partition: 1, Offset: 5, Metadata: "reset"},
+	}, offsets)
+}
+
+func TestConsumerGroupDescriptionFromConfluent(t *testing.T) {
+	t.Parallel()
+
+	topic := "orders"
+	rack := "rack-a"
+	description := consumerGroupDescriptionFromConfluent(ckafka.ConsumerGroupDescription{
+		GroupID:           "group",
+		State:             ckafka.ConsumerGroupStatePreparingRebalance,
+		PartitionAssignor: "range",
+		Coordinator:       ckafka.Node{ID: 2, Host: "broker-2", Port: 9092, Rack: &rack},
+		Members: []ckafka.MemberDescription{{
+			ClientID:        "client",
+			GroupInstanceID: "instance-1",
+			ConsumerID:      "member-1",
+			Host:            "/10.0.0.1",
+			Assignment: ckafka.MemberAssignment{TopicPartitions: []ckafka.TopicPartition{
+				{Topic: &topic, Partition: 3, Offset: ckafka.OffsetInvalid},
+			}},
+		}},
+	})
+
+	assert.Equal(t, "group", description.GroupID)
+	assert.Equal(t, "preparingrebalance", description.State)
+	assert.Equal(t, "range", description.PartitionAssignor)
+	assert.Equal(t, Node{ID: 2, Host: "broker-2", Port: 9092, Rack: "rack-a"}, description.Coordinator)
+	require.Len(t, description.Members, 1)
+	assert.Equal(t, "member-1", description.Members[0].MemberID)
+	assert.Equal(t, "instance-1", description.Members[0].GroupInstanceID)
+	assert.Equal(t, "client", description.Members[0].ClientID)
+	assert.Equal(t, "orders", description.Members[0].Assignment[0].Topic)
+	assert.Equal(t, 3, description.Members[0].Assignment[0].Partition)
+	require.NoError(t, description.Error)
+
+	failed := consumerGroupDescriptionFromConfluent(ckafka.ConsumerGroupDescription{
+		GroupID: "missing",
+		Error:   ckafka.NewError(ckafka.ErrGroupIDNotFound, "group id not found", false),
+	})
+	require.Error(t, failed.Error)
+	assert.Empty(t, failed.Members)
+}
+
+func TestGroupOffsetsFromConfluent(t *testing.T) {
+	t.Parallel()
+
+	orders := "orders"
+	audit := "audit"
+	offsets, err := groupOffsetsFromConfluent([]ckafka.ConsumerGroupTopicPartitions{{
+		Group: "group",
+		Partitions: []ckafka.TopicPartition{
+			{Topic: &orders, Partition: 1, Offset: 10},
+			{Topic: &orders, Partition: 0, Offset: ckafka.OffsetInvalid},
+			{Topic: &audit, Partition: 0, Offset: 3},
+		},
+	}}, failedListGroupOffsets)
+	require.NoError(t, err)
+	assert.Equal(t, []TopicPartition{
+		{Topic: "audit", Partition: 0, Offset: 3},
+		{Topic: "orders", Partition: 0, Offset: noCommittedOffset},
+		{Topic: "orders", Partition: 1, Offset: 10},
+	}, offsets)
+
+	_, err = groupOffsetsFromConfluent([]ckafka.ConsumerGroupTopicPartitions{{
+		Group: "group",
+		Partitions: []ckafka.TopicPartition{{
+			Topic: &orders, Partition: 2,
+			Error: ckafka.NewError(ckafka.ErrUnknownTopicOrPart, "unknown partition", false),
+		}},
+	}}, failedAlterGroupOffsets)
+	var xk6Err *Xk6KafkaError
+	require.ErrorAs(t, err, &xk6Err)
+	assert.Equal(t, failedAlterGroupOffsets, xk6Err.Code)
+	assert.Contains(t, err.Error(), "Offset of orders [2] failed for consumer group group.")
+}
+
+func TestAdminClientClassConsumerGroups(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+	require.NoError(t, mockCluster.CreateTopic("admin-groups-topic", 1, 1))
+
+	test := getTestModuleInstance(t)
+	adminClient := test.module.adminClientClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+		})},
+	})
+	require.NotNil(t, adminClient)
+	defer func() {
+		closeAdmin := adminClient.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		closeAdmin(sobek.FunctionCall{})
+	}()
+
+	describeGroups := adminClient.Get("describeConsumerGroups").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		describeGroups(sobek.FunctionCall{})
+	}, ErrNotEnoughArguments.Error())
+	deleteGroups := adminClient.Get("deleteConsumerGroups").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		deleteGroups(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue("group")}})
+	}, "Invalid consumer groups, OriginalError: expected array, got string")
+
+	alterOffsets := adminClient.Get("alterConsumerGroupOffsets").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		alterOffsets(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue("admin-groups")}})
+	}, ErrNotEnoughArguments.Error())
+	alterOffsets(sobek.FunctionCall{Arguments: []sobek.Value{
+		test.rt.ToValue("admin-groups"),
+		test.rt.ToValue([]map[string]any{{"topic": "admin-groups-topic", "partition": 0, "offset": 7}}),
+	}})
+
+	listOffsets := adminClient.Get("listConsumerGroupOffsets").Export().(func(sobek.FunctionCall) sobek.Value)
+	offsets := listOffsets(sobek.FunctionCall{Arguments: []sobek.Value{
+		test.rt.ToValue("admin-groups"),
+		test.rt.ToValue([]map[string]any{{"topic": "admin-groups-topic", "partition": 0}}),
+	}}).Export().([]map[string]any)
+	require.Len(t, offsets, 1)
+	assert.Equal(t, "admin-groups-topic", offsets[0]["topic"])
+	assert.Equal(t, int64(7), offsets[0]["offset"])
+	assert.Empty(t, offsets[0]["metadata"])
+}
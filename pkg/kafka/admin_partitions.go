@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const (
+	defaultPartitionWaitTimeout  = 30 * time.Second
+	partitionWaitPollingInterval = 100 * time.Millisecond
+)
+
+type CreatePartitionsOptions struct {
+	// ValidateOnly makes the brokers check the request without adding the
+	// partitions.
+	ValidateOnly bool `json:"validateOnly"`
+}
+
+type WaitForPartitionsOptions struct {
+	// Timeout defaults to 30 seconds.
+	Timeout Duration `json:"timeout"`
+}
+
+// CreatePartitions increases the partition count of topic to newTotal.
+// Assignments, if any, hold the replica broker ids of each added partition,
+// in order, with the preferred leader first.
+func (a *AdminClient) CreatePartitions(
+	ctx context.Context,
+	topic string,
+	newTotal int,
+	assignments [][]int32,
+	options CreatePartitionsOptions,
+) error {
+	if a == nil || a.client == nil {
+		return newMissingConfigError("admin client")
+	}
+	ctx = ensureContext(ctx)
+	if topic == "" {
+		return newInvalidConfigError("topic config", errTopicMustNotBeEmpty)
+	}
+	if newTotal <= 0 {
+		return newInvalidConfigError("topic config", errPartitionCountInvalid)
+	}
+
+	results, err := a.client.CreatePartitions(ctx, []ckafka.PartitionsSpecification{{
+		Topic:             topic,
+		IncreaseTo:        newTotal,
+		ReplicaAssignment: assignments,
+	}}, ckafka.SetAdminValidateOnly(options.ValidateOnly))
+	if err != nil {
+		return NewXk6KafkaError(failedCreatePartitions, "Failed to create partitions.", err)
+	}
+
+	return adminTopicResultError(results, failedCreatePartitions)
+}
+
+// WaitForPartitions waits until the metadata of topic has at least count
+// partitions, all with an elected leader.
+func (a *AdminClient) WaitForPartitions(
+	ctx context.Context,
+	topic string,
+	count int,
+	options WaitForPartitionsOptions,
+) (*TopicMetadata, error) {
+	if a == nil || a.client == nil {
+		return nil, newMissingConfigError("admin client")
+	}
+	if topic == "" {
+		return nil, newInvalidConfigError("topic config", errTopicMustNotBeEmpty)
+	}
+	if count <= 0 {
+		return nil, newInvalidConfigError("topic config", errPartitionCountInvalid)
+	}
+
+	timeout := options.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultPartitionWaitTimeout
+	}
+	ctx, cancel := context.WithTimeout(ensureContext(ctx), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(partitionWaitPollingInterval)
+	defer ticker.Stop()
+	// metadataErr is the error of the last metadata request, unless it ran
+	// into the timeout. Failed requests are retried, since the brokers may
+	// not know about the topic yet.
+	var metadataErr error
+	for {
+		metadata, err := a.GetMetadata(ctx, topic)
+		var kafkaErr ckafka.Error
+		switch {
+		case err == nil && partitionsReady(metadata, count):
+			return metadata, nil
+		case err == nil, errors.As(err, &kafkaErr) && kafkaErr.Code() == ckafka.ErrTimedOut:
+			metadataErr = nil
+		default:
+			metadataErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if metadataErr == nil {
+				metadataErr = ctx.Err()
+			}
+			return nil, NewXk6KafkaError(failedWaitPartitions, fmt.Sprintf(
+				"Topic %s does not have %d partitions with a leader.", topic, count), metadataErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+func partitionsReady(metadata *TopicMetadata, count int) bool {
+	if metadata.Error != nil || len(metadata.Partitions) < count {
+		return false
+	}
+	for _, partition := range metadata.Partitions {
+		if partition.Error != nil || partition.Leader < 0 {
+			return false
+		}
+	}
+	return true
+}
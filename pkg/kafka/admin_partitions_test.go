@@ -0,0 +1,121 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminClientCreatePartitionsValidatesArguments(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	ctx := context.Background()
+	err = admin.CreatePartitions(ctx, "", 3, nil, CreatePartitionsOptions{})
+	require.ErrorIs(t, err, errTopicMustNotBeEmpty)
+	err = admin.CreatePartitions(ctx, "topic", 0, nil, CreatePartitionsOptions{})
+	require.ErrorIs(t, err, errPartitionCountInvalid)
+	_, err = admin.WaitForPartitions(ctx, "topic", 0, WaitForPartitionsOptions{})
+	require.ErrorIs(t, err, errPartitionCountInvalid)
+
+	var nilAdmin *AdminClient
+	require.Error(t, nilAdmin.CreatePartitions(ctx, "topic", 3, nil, CreatePartitionsOptions{}))
+	_, err = nilAdmin.WaitForPartitions(ctx, "topic", 3, WaitForPartitionsOptions{})
+	require.Error(t, err)
+}
+
+func TestAdminClientWaitForPartitions(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+	require.NoError(t, mockCluster.CreateTopic("wait-partitions-topic", 3, 1))
+
+	admin, err := NewAdminClientFromConnectionConfig(&ConnectionConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+	})
+	require.NoError(t, err)
+	defer func() { _ = admin.Close() }()
+
+	metadata, err := admin.WaitForPartitions(
+		context.Background(), "wait-partitions-topic", 3, WaitForPartitionsOptions{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, metadata.Partitions, 3)
+
+	_, err = admin.WaitForPartitions(context.Background(), "wait-partitions-topic", 5, WaitForPartitionsOptions{
+		Timeout: Duration{Duration: 300 * time.Millisecond},
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "Topic wait-partitions-topic does not have 5 partitions with a leader.")
+}
+
+func TestPartitionsReady(t *testing.T) {
+	t.Parallel()
+
+	leaders := func(leaders ...int32) *TopicMetadata {
+		metadata := &TopicMetadata{}
+		for i, leader := range leaders {
+			metadata.Partitions = append(metadata.Partitions, PartitionInfo{ID: int32(i), Leader: leader})
+		}
+		return metadata
+	}
+
+	assert.True(t, partitionsReady(leaders(1, 1, 1), 3))
+	assert.True(t, partitionsReady(leaders(1, 1, 1), 2))
+	assert.False(t, partitionsReady(leaders(1, 1), 3))
+	assert.False(t, partitionsReady(leaders(1, -1, 1), 3))
+
+	failed := leaders(1)
+	failed.Error = ckafka.NewError(ckafka.ErrLeaderNotAvailable, "leader not available", false)
+	assert.False(t, partitionsReady(failed, 1))
+}
+
+func TestAdminClientClassPartitions(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+	require.NoError(t, mockCluster.CreateTopic("admin-partitions-topic", 2, 1))
+
+	test := getTestModuleInstance(t)
+	adminClient := test.module.adminClientClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+		})},
+	})
+	require.NotNil(t, adminClient)
+	defer func() {
+		closeAdmin := adminClient.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		closeAdmin(sobek.FunctionCall{})
+	}()
+
+	createPartitions := adminClient.Get("createPartitions").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		createPartitions(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue("admin-partitions-topic")}})
+	}, ErrNotEnoughArguments.Error())
+	requireGoErrorMessage(t, func() {
+		createPartitions(sobek.FunctionCall{Arguments: []sobek.Value{
+			test.rt.ToValue("admin-partitions-topic"), test.rt.ToValue(4), test.rt.ToValue(map[string]any{}),
+		}})
+	}, "Invalid replica assignments, OriginalError: expected array, got map[string]interface {}")
+
+	waitForPartitions := adminClient.Get("waitForPartitions").Export().(func(sobek.FunctionCall) sobek.Value)
+	metadata := waitForPartitions(sobek.FunctionCall{Arguments: []sobek.Value{
+		test.rt.ToValue("admin-partitions-topic"), test.rt.ToValue(2), test.rt.ToValue(map[string]any{"timeout": "5s"}),
+	}}).Export().(map[string]any)
+	assert.Equal(t, "admin-partitions-topic", metadata["topic"])
+	assert.Len(t, metadata["partitions"], 2)
+}
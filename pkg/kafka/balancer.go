@@ -1,3 +1,248 @@
 package kafka
 
-type BalancerKeyFunc func(key []byte, partitions ...int) (partition int)
+import (
+	"context"
+	"hash/crc32"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// producerPartitionsMaxAge bounds how long cached topic partitions are reused
+// before the producer asks the cluster for fresh metadata.
+const producerPartitionsMaxAge = 30 * time.Second
+
+// BalancerKeyFunc is a custom balancer, called with the message key and the
+// partition IDs of its topic.
+type BalancerKeyFunc func(key []byte, partitions []int) (partition int)
+
+// partitionBalancer picks a partition for a message from the sorted
+// partition IDs of its topic. The implementations mirror the kafka-go
+// balancers used by v1, so keyed messages keep landing on the same partitions.
+type partitionBalancer interface {
+	Balance(msg Message, partitions ...int) (partition int)
+}
+
+func newPartitionBalancer(name string) (partitionBalancer, error) {
+	switch name {
+	case "":
+		//nolint: nilnil // without a balancer librdkafka picks the partition
+		return nil, nil
+	case balancerRoundRobin:
+		return &roundRobinBalancer{}, nil
+	case balancerLeastBytes:
+		return &leastBytesBalancer{}, nil
+	case balancerHash:
+		return &hashBalancer{}, nil
+	case balancerCrc32:
+		return crc32Balancer{}, nil
+	case balancerMurmur2:
+		return murmur2Balancer{}, nil
+	default:
+		return nil, newInvalidConfigError("writer config", errUnknownBalancer)
+	}
+}
+
+// keyFuncBalancer calls a script-provided balancer with the message key and
+// an array of every partition ID of the topic. The function is bound to the VU
+// runtime, so it must only be called from the goroutine running the VU. The
+// producer picks partitions before handing messages to librdkafka, which keeps
+// the delivery goroutine free of JS calls.
+type keyFuncBalancer BalancerKeyFunc
+
+func (b keyFuncBalancer) Balance(msg Message, partitions ...int) int {
+	return b(msg.Key, partitions)
+}
+
+// roundRobinBalancer distributes messages evenly across all partitions.
+type roundRobinBalancer struct {
+	offset atomic.Uint32
+}
+
+func (b *roundRobinBalancer) Balance(_ Message, partitions ...int) int {
+	offset := b.offset.Add(1) - 1
+	return partitions[offset%uint32(len(partitions))]
+}
+
+// leastBytesBalancer sends each message to the partition of its topic that has
+// received the fewest bytes so far.
+type leastBytesBalancer struct {
+	mu       sync.Mutex
+	counters map[string]map[int]uint64
+}
+
+func (b *leastBytesBalancer) Balance(msg Message, partitions ...int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.counters == nil {
+		b.counters = make(map[string]map[int]uint64)
+	}
+	counters, ok := b.counters[msg.Topic]
+	if !ok {
+		counters = make(map[int]uint64, len(partitions))
+		b.counters[msg.Topic] = counters
+	}
+
+	partition := partitions[0]
+	for _, candidate := range partitions[1:] {
+		if counters[candidate] < counters[partition] {
+			partition = candidate
+		}
+	}
+
+	counters[partition] += uint64(len(msg.Key)) + uint64(len(msg.Value))
+	return partition
+}
+
+// hashBalancer uses the FNV-1a hash of the key, like Sarama and kafka-go.
+// Messages without a key are distributed round-robin.
+type hashBalancer struct {
+	rr roundRobinBalancer
+}
+
+func (b *hashBalancer) Balance(msg Message, partitions ...int) int {
+	if msg.Key == nil {
+		return b.rr.Balance(msg, partitions...)
+	}
+
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(msg.Key)
+
+	//nolint:gosec // the uint32 to int32 conversion matches Sarama's hashPartitioner
+	index := int32(hasher.Sum32()) % int32(len(partitions))
+	if index < 0 {
+		index = -index
+	}
+
+	return partitions[index]
+}
+
+// crc32Balancer matches librdkafka's "consistent_random" partitioner: like
+// kafka-go, messages with a nil or empty key go to a random partition.
+type crc32Balancer struct{}
+
+func (crc32Balancer) Balance(msg Message, partitions ...int) int {
+	if len(msg.Key) == 0 {
+		return randomPartition(partitions)
+	}
+
+	index := crc32.ChecksumIEEE(msg.Key) % uint32(len(partitions))
+	return partitions[index]
+}
+
+// murmur2Balancer matches the Java client's default partitioner for keyed
+// messages. Like kafka-go, messages without a key go to a random partition.
+type murmur2Balancer struct{}
+
+func (murmur2Balancer) Balance(msg Message, partitions ...int) int {
+	if msg.Key == nil {
+		return randomPartition(partitions)
+	}
+
+	index := (murmur2(msg.Key) & 0x7fffffff) % uint32(len(partitions))
+	return partitions[index]
+}
+
+func randomPartition(partitions []int) int {
+	//nolint:gosec // partition choice needs no cryptographic randomness
+	return partitions[rand.IntN(len(partitions))]
+}
+
+// murmur2 is a port of org.apache.kafka.common.utils.Utils.murmur2.
+func murmur2(data []byte) uint32 {
+	const (
+		seed uint32 = 0x9747b28c
+		m    uint32 = 0x5bd1e995
+		r           = 24
+	)
+
+	length := len(data)
+	//nolint:gosec // Java hashes the int length the same way
+	h := seed ^ uint32(length)
+
+	for i := 0; i+4 <= length; i += 4 {
+		k := uint32(data[i]) |
+			uint32(data[i+1])<<8 |
+			uint32(data[i+2])<<16 |
+			uint32(data[i+3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+
+	tail := length &^ 3
+	switch length % 4 {
+	case 3:
+		h ^= uint32(data[tail+2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[tail+1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[tail])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+
+	return h
+}
+
+type cachedTopicPartitions struct {
+	partitions []int
+	fetchedAt  time.Time
+}
+
+// topicPartitions returns the sorted partition IDs of topic, refreshing the
+// cached metadata once it is older than producerPartitionsMaxAge.
+func (p *Producer) topicPartitions(ctx context.Context, topic string) ([]int, error) {
+	p.partitionsMu.Lock()
+	cached, ok := p.partitions[topic]
+	p.partitionsMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < producerPartitionsMaxAge {
+		return cached.partitions, nil
+	}
+
+	metadata, err := p.client.GetMetadata(&topic, false, confluentMetadataTimeoutMs(ctx))
+	if err != nil {
+		return nil, NewXk6KafkaError(failedGetMetadata, "Failed to get topic metadata.", err)
+	}
+
+	topicMetadata, ok := metadata.Topics[topic]
+	if !ok {
+		return nil, NewXk6KafkaError(
+			failedGetMetadata,
+			"Topic metadata was not returned.",
+			errTopicMetadataNotFound,
+		)
+	}
+	if err := normalizeConfluentError(topicMetadata.Error); err != nil {
+		return nil, NewXk6KafkaError(failedGetMetadata, "Failed to get topic metadata.", err)
+	}
+	if len(topicMetadata.Partitions) == 0 {
+		return nil, NewXk6KafkaError(failedGetMetadata, "Topic has no partitions.", errTopicHasNoPartitions)
+	}
+
+	partitions := make([]int, 0, len(topicMetadata.Partitions))
+	for _, partition := range topicMetadata.Partitions {
+		partitions = append(partitions, int(partition.ID))
+	}
+	slices.Sort(partitions)
+
+	p.partitionsMu.Lock()
+	if p.partitions == nil {
+		p.partitions = make(map[string]cachedTopicPartitions)
+	}
+	p.partitions[topic] = cachedTopicPartitions{partitions: partitions, fetchedAt: time.Now()}
+	p.partitionsMu.Unlock()
+
+	return partitions, nil
+}
@@ -0,0 +1,253 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMurmur2MatchesJavaClient(t *testing.T) {
+	// Expected values come from org.apache.kafka.common.utils.Utils.murmur2.
+	cases := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+
+	for input, expected := range cases {
+		//nolint:gosec // Java returns murmur2 as a signed int
+		assert.Equal(t, expected, int32(murmur2([]byte(input))), input)
+	}
+}
+
+func TestPartitionBalancers(t *testing.T) {
+	partitions := []int{0, 1, 2}
+
+	t.Run("round robin", func(t *testing.T) {
+		balancer, err := newPartitionBalancer(balancerRoundRobin)
+		require.NoError(t, err)
+
+		var picked []int
+		for range 4 {
+			picked = append(picked, balancer.Balance(Message{}, partitions...))
+		}
+		assert.Equal(t, []int{0, 1, 2, 0}, picked)
+	})
+
+	t.Run("least bytes", func(t *testing.T) {
+		balancer, err := newPartitionBalancer(balancerLeastBytes)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, balancer.Balance(Message{Topic: "a", Value: []byte("large-value")}, partitions...))
+		assert.Equal(t, 1, balancer.Balance(Message{Topic: "a", Value: []byte("v")}, partitions...))
+		assert.Equal(t, 2, balancer.Balance(Message{Topic: "a", Value: []byte("v")}, partitions...))
+		assert.Equal(t, 1, balancer.Balance(Message{Topic: "a", Value: []byte("v")}, partitions...))
+		assert.Equal(t, 0, balancer.Balance(Message{Topic: "b", Value: []byte("v")}, partitions...))
+	})
+
+	t.Run("keyed balancers are stable", func(t *testing.T) {
+		for _, name := range []string{balancerHash, balancerCrc32, balancerMurmur2} {
+			balancer, err := newPartitionBalancer(name)
+			require.NoError(t, err)
+
+			msg := Message{Key: []byte("stable-key")}
+			first := balancer.Balance(msg, partitions...)
+			assert.Contains(t, partitions, first, name)
+			for range 5 {
+				assert.Equal(t, first, balancer.Balance(msg, partitions...), name)
+			}
+		}
+	})
+
+	t.Run("murmur2 matches the Java partitioner", func(t *testing.T) {
+		// Java: Utils.toPositive(Utils.murmur2("foobar")) % 10 == 6.
+		assert.Equal(t, 6, murmur2Balancer{}.Balance(Message{Key: []byte("foobar")}, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9))
+	})
+
+	t.Run("hash without key falls back to round robin", func(t *testing.T) {
+		balancer := &hashBalancer{}
+		assert.Equal(t, 0, balancer.Balance(Message{}, partitions...))
+		assert.Equal(t, 1, balancer.Balance(Message{}, partitions...))
+	})
+
+	t.Run("crc32 and murmur2 without key pick a random partition", func(t *testing.T) {
+		for _, name := range []string{balancerCrc32, balancerMurmur2} {
+			balancer, err := newPartitionBalancer(name)
+			require.NoError(t, err)
+
+			picked := make(map[int]bool)
+			for range 100 {
+				partition := balancer.Balance(Message{}, partitions...)
+				assert.Contains(t, partitions, partition, name)
+				picked[partition] = true
+			}
+			assert.Len(t, picked, len(partitions), name)
+		}
+	})
+
+	t.Run("no balancer", func(t *testing.T) {
+		balancer, err := newPartitionBalancer("")
+		require.NoError(t, err)
+		assert.Nil(t, balancer)
+	})
+}
+
+func TestDecodeProduceConfigTracksExplicitPartition(t *testing.T) {
+	runtime := sobek.New()
+
+	value, err := runtime.RunString(`({ messages: [
+		{ value: "a", partition: 0 },
+		{ value: "b" },
+		{ value: "c", partition: undefined },
+		{ value: "d", partition: null },
+	] })`)
+	require.NoError(t, err)
+
+	produceConfig := decodeProduceConfig(runtime, value, nil)
+	require.NotNil(t, produceConfig)
+	require.Len(t, produceConfig.Messages, 4)
+	assert.True(t, produceConfig.Messages[0].partitionSet)
+	assert.False(t, produceConfig.Messages[1].partitionSet)
+	assert.False(t, produceConfig.Messages[2].partitionSet)
+	assert.False(t, produceConfig.Messages[3].partitionSet)
+}
+
+func TestProducerPartitioningWithMockCluster(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	topicName := "balancer-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 3, 1))
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:  []string{mockCluster.BootstrapServers()},
+		Topic:    topicName,
+		Balancer: balancerMurmur2,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Value: []byte("explicit"), Partition: 2, partitionSet: true},
+		{Key: []byte("foobar"), Value: []byte("balanced")},
+	}))
+
+	partitions, err := producer.topicPartitions(ctx, topicName)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, partitions)
+
+	consumeFrom := func(partition int) []Message {
+		consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+			Brokers:     []string{mockCluster.BootstrapServers()},
+			Topic:       topicName,
+			Partition:   partition,
+			StartOffset: firstOffset,
+		})
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, consumer.Close())
+		}()
+
+		messages, err := consumer.Consume(ctx, 1)
+		require.NoError(t, err)
+		return messages
+	}
+
+	explicit := consumeFrom(2)
+	require.Len(t, explicit, 1)
+	assert.Equal(t, []byte("explicit"), explicit[0].Value)
+
+	// murmur2("foobar") lands on partition 0 of 3, like the Java client.
+	balanced := consumeFrom(0)
+	require.Len(t, balanced, 1)
+	assert.Equal(t, []byte("balanced"), balanced[0].Value)
+}
+
+func TestProducerClassJSBalancerWithMockCluster(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	test.vu.CtxField = ctx
+
+	topicName := "js-balancer-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 3, 1))
+
+	balancer, err := test.rt.RunString(`
+		var balancerCalls = [];
+		(function (key, partitions) {
+			balancerCalls.push(partitions);
+			return String.fromCharCode(...key) === "unknown" ? 7 : partitions[partitions.length - 1];
+		})
+	`)
+	require.NoError(t, err)
+
+	producer := test.module.producerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":  []string{mockCluster.BootstrapServers()},
+			"topic":    topicName,
+			"balancer": balancer,
+		})},
+	})
+	require.NotNil(t, producer)
+
+	produce := producer.Get("produce").Export().(func(sobek.FunctionCall) sobek.Value)
+	produce(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"messages": []map[string]any{{
+				"key":   "routed",
+				"value": "routed-value",
+			}},
+		})},
+	})
+
+	var calls [][]int
+	require.NoError(t, test.rt.ExportTo(test.rt.Get("balancerCalls"), &calls))
+	assert.Equal(t, [][]int{{0, 1, 2}}, calls)
+
+	requireGoErrorMessage(t, func() {
+		produce(sobek.FunctionCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+				"messages": []map[string]any{{
+					"key":   "unknown",
+					"value": "unknown-value",
+				}},
+			})},
+		})
+	}, "Balancer returned an unknown partition., OriginalError: balancer returned a partition the topic does not have: 7")
+
+	consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:     []string{mockCluster.BootstrapServers()},
+		Topic:       topicName,
+		Partition:   2,
+		StartOffset: firstOffset,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, consumer.Close())
+	}()
+
+	messages, err := consumer.Consume(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, []byte("routed-value"), messages[0].Value)
+}
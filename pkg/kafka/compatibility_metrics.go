@@ -63,6 +63,12 @@ func (k *Kafka) reportProducerCompatibilityMetrics(
 		errorValue = 1 / float64(len(groups))
 	}
 
+	current, previous := producer.stats.take()
+	retries := 0.0
+	if current != nil {
+		retries = counterDelta(current.txRetries(), previous.txRetries()) / float64(len(groups))
+	}
+
 	now := time.Now()
 	for topic, group := range groups {
 		sampleTags := ctm.Tags.With("topic", topic)
@@ -167,7 +173,7 @@ func (k *Kafka) reportProducerCompatibilityMetrics(
 						Metric: k.metrics.WriterRetries,
 						Tags:   sampleTags,
 					},
-					Value:    0,
+					Value:    retries,
 					Metadata: ctm.Metadata,
 				},
 				{
@@ -256,6 +262,8 @@ func (k *Kafka) reportProducerCompatibilityMetrics(
 			Time: now,
 		})
 	}
+
+	k.reportClientStats(ctm, state, current, previous)
 }
 
 func (k *Kafka) reportConsumerCompatibilityMetrics(
@@ -297,6 +305,21 @@ func (k *Kafka) reportConsumerCompatibilityMetrics(
 		}
 	}
 
+	// Once the consumer emits librdkafka statistics, dials and queue lengths
+	// come from them instead of the consume call.
+	statsReceived := consumer.stats.received()
+	current, previous := consumer.stats.take()
+	latest := current
+	if latest == nil {
+		latest = previous
+	}
+	rebalanceCount, rebalanceDurations := consumer.rebalances.take()
+	rebalances := float64(rebalanceCount) / float64(len(groups))
+	queueCapacity := float64(librdkafkaQueuedMinMessages)
+	if _, ok := consumer.config["queued.min.messages"]; ok {
+		queueCapacity = compatibilityConfigFloat(consumer.config, "queued.min.messages")
+	}
+
 	now := time.Now()
 	clientID := compatibilityConfigString(consumer.config, "client.id")
 	for key, group := range groups {
@@ -317,12 +340,15 @@ func (k *Kafka) reportConsumerCompatibilityMetrics(
 			fetches = compatibilityConsumerFetches(group.messages, len(groups), consumeErr)
 			readTime = elapsed
 		}
-
-		lag := float64(0)
-		if group.highWaterMark > group.lastOffset {
-			lag = float64(group.highWaterMark - group.lastOffset)
+		if statsReceived {
+			dials = 0
+			if current != nil {
+				dials = counterDelta(current.connects(), previous.connects()) / float64(len(groups))
+			}
 		}
 
+		lag := float64(consumerLag(group.highWaterMark, group.lastOffset+1))
+
 		metrics.PushIfNotDone(ctx, state.Samples, metrics.ConnectedSamples{
 			Samples: []metrics.Sample{
 				{
@@ -367,7 +393,7 @@ func (k *Kafka) reportConsumerCompatibilityMetrics(
 						Metric: k.metrics.ReaderRebalances,
 						Tags:   sampleTags,
 					},
-					Value:    0,
+					Value:    rebalances,
 					Metadata: ctm.Metadata,
 				},
 				{
@@ -484,7 +510,7 @@ func (k *Kafka) reportConsumerCompatibilityMetrics(
 						Metric: k.metrics.ReaderQueueLength,
 						Tags:   sampleTags,
 					},
-					Value:    0,
+					Value:    latest.fetchQueueLength(key.topic, key.partition),
 					Metadata: ctm.Metadata,
 				},
 				{
@@ -493,7 +519,7 @@ func (k *Kafka) reportConsumerCompatibilityMetrics(
 						Metric: k.metrics.ReaderQueueCapacity,
 						Tags:   sampleTags,
 					},
-					Value:    0,
+					Value:    queueCapacity,
 					Metadata: ctm.Metadata,
 				},
 			},
@@ -501,6 +527,42 @@ func (k *Kafka) reportConsumerCompatibilityMetrics(
 			Time: now,
 		})
 	}
+
+	// Partitions read in this call already reported their lag above.
+	for key, lag := range consumer.takeSampledLags() {
+		if _, ok := groups[key]; ok {
+			continue
+		}
+
+		sampleTags := ctm.Tags.With("topic", key.topic)
+		sampleTags = sampleTags.With("clientid", clientID)
+		sampleTags = sampleTags.With("partition", strconv.Itoa(key.partition))
+		metrics.PushIfNotDone(ctx, state.Samples, metrics.Sample{
+			Time: now,
+			TimeSeries: metrics.TimeSeries{
+				Metric: k.metrics.ReaderLag,
+				Tags:   sampleTags,
+			},
+			Value:    float64(lag),
+			Metadata: ctm.Metadata,
+		})
+	}
+
+	clientTags := ctm.Tags.With("clientid", clientID)
+	for _, duration := range rebalanceDurations {
+		metrics.PushIfNotDone(ctx, state.Samples, metrics.Sample{
+			Time: now,
+			TimeSeries: metrics.TimeSeries{
+				Metric: k.metrics.ReaderRebalanceTime,
+				Tags:   clientTags,
+			},
+			Value:    metrics.D(duration),
+			Metadata: ctm.Metadata,
+		})
+	}
+	k.reportConsumerCommits(consumer)
+
+	k.reportClientStats(ctm, state, current, previous)
 }
 
 func collectProducerMetricGroups(producer *Producer, messages []Message) map[string]producerMetricGroup {
@@ -551,7 +613,7 @@ func consumerCompatibilityTopic(consumer *Consumer) string {
 		return ""
 	}
 
-	return consumer.topic
+	return consumer.assignedTopic()
 }
 
 func compatibilityMessageBytes(msg Message) int {
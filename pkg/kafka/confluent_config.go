@@ -2,7 +2,9 @@ package kafka
 
 import (
 	"context"
+	"fmt"
 	"maps"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +17,9 @@ const defaultConfluentTimeout = 5 * time.Second
 const (
 	confluentAutoOffsetResetEarliest = "earliest"
 	confluentAutoOffsetResetLatest   = "latest"
+
+	confluentIsolationReadCommitted   = "read_committed"
+	confluentIsolationReadUncommitted = "read_uncommitted"
 )
 
 func ensureContext(ctx context.Context) context.Context {
@@ -214,6 +219,9 @@ func writerConfigToConfluentConfigMap(writerConfig *WriterConfig) (ckafka.Config
 	if err := setConfluentConfigValue(config, "go.delivery.report.fields", "none"); err != nil {
 		return nil, err
 	}
+	if err := setConfluentConfigValue(config, "statistics.interval.ms", defaultStatisticsIntervalMs); err != nil {
+		return nil, err
+	}
 
 	if writerConfig.BatchSize > 0 {
 		if err := setConfluentConfigValue(config, "batch.num.messages", writerConfig.BatchSize); err != nil {
@@ -257,7 +265,17 @@ func writerConfigToConfluentConfigMap(writerConfig *WriterConfig) (ckafka.Config
 			return nil, err
 		}
 	}
-	acksValue, err := confluentRequiredAcks(writerConfig.RequiredAcks)
+	requiredAcks := writerConfig.RequiredAcks
+	if writerConfig.TransactionalID != "" {
+		requiredAcks, err = confluentTransactionalRequiredAcks(requiredAcks, writerConfig.requiredAcksSet)
+		if err != nil {
+			return nil, err
+		}
+		if err := setConfluentConfigValue(config, "transactional.id", writerConfig.TransactionalID); err != nil {
+			return nil, err
+		}
+	}
+	acksValue, err := confluentRequiredAcks(requiredAcks)
 	if err != nil {
 		return nil, err
 	}
@@ -265,6 +283,12 @@ func writerConfigToConfluentConfigMap(writerConfig *WriterConfig) (ckafka.Config
 		return nil, err
 	}
 
+	if err := applyConfluentRawConfig(
+		config, writerConfig.Config, writerConfig.StrictConfig, "writer config",
+	); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -281,6 +305,9 @@ func readerConfigToConfluentConfigMap(readerConfig *ReaderConfig) (ckafka.Config
 	if err := applyConfluentSecurityConfig(config, readerConfig.SASL, readerConfig.TLS); err != nil {
 		return nil, err
 	}
+	if err := setConfluentConfigValue(config, "statistics.interval.ms", defaultStatisticsIntervalMs); err != nil {
+		return nil, err
+	}
 
 	if readerConfig.MinBytes > 0 {
 		if err := setConfluentConfigValue(config, "fetch.min.bytes", readerConfig.MinBytes); err != nil {
@@ -319,6 +346,30 @@ func readerConfigToConfluentConfigMap(readerConfig *ReaderConfig) (ckafka.Config
 			return nil, err
 		}
 	}
+	strategy, err := confluentAssignmentStrategy(readerConfig.GroupBalancers, readerConfig.Rack)
+	if err != nil {
+		return nil, err
+	}
+	if strategy != "" {
+		if err := setConfluentConfigValue(config, "partition.assignment.strategy", strategy); err != nil {
+			return nil, err
+		}
+	}
+	if readerConfig.Rack != "" {
+		if err := setConfluentConfigValue(config, "client.rack", readerConfig.Rack); err != nil {
+			return nil, err
+		}
+	}
+
+	isolationLevel, err := confluentIsolationLevel(readerConfig.IsolationLevel)
+	if err != nil {
+		return nil, err
+	}
+	if isolationLevel != "" {
+		if err := setConfluentConfigValue(config, "isolation.level", isolationLevel); err != nil {
+			return nil, err
+		}
+	}
 	if readerConfig.CommitInterval > 0 {
 		if err := setConfluentConfigValue(
 			config,
@@ -329,11 +380,24 @@ func readerConfigToConfluentConfigMap(readerConfig *ReaderConfig) (ckafka.Config
 		}
 	}
 
+	if readerConfig.GroupInstanceID != "" && readerConfig.GroupID == "" {
+		return nil, newInvalidConfigError("reader config", errGroupInstanceIDRequiresGroup)
+	}
 	if readerConfig.GroupID != "" {
 		if err := setConfluentConfigValue(config, "group.id", readerConfig.GroupID); err != nil {
 			return nil, err
 		}
-		if err := setConfluentConfigValue(config, "enable.auto.commit", false); err != nil {
+		if readerConfig.GroupInstanceID != "" {
+			if err := setConfluentConfigValue(config, "group.instance.id", readerConfig.GroupInstanceID); err != nil {
+				return nil, err
+			}
+		}
+		// Group consumers commit automatically every commitInterval, like v1.
+		if err := setConfluentConfigValue(
+			config,
+			"enable.auto.commit",
+			readerConfig.CommitInterval > 0,
+		); err != nil {
 			return nil, err
 		}
 
@@ -354,6 +418,12 @@ func readerConfigToConfluentConfigMap(readerConfig *ReaderConfig) (ckafka.Config
 		}
 	}
 
+	if err := applyConfluentRawConfig(
+		config, readerConfig.Config, readerConfig.StrictConfig, "reader config",
+	); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -381,6 +451,12 @@ func connectionConfigToConfluentConfigMap(connectionConfig *ConnectionConfig) (c
 		return nil, err
 	}
 
+	if err := applyConfluentRawConfig(
+		config, connectionConfig.Config, connectionConfig.StrictConfig, "connection config",
+	); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -400,6 +476,90 @@ func confluentRequiredAcks(requiredAcks int) (string, error) {
 	}
 }
 
+// confluentAssignmentStrategy maps the GROUP_BALANCER_* constants to
+// librdkafka's "partition.assignment.strategy", keeping their order. Rack
+// affinity is not an assignor in librdkafka: it needs the consumer's rack,
+// which is sent as "client.rack" so the rack-aware assignors and follower
+// fetching can use it. Without a strategy, librdkafka's default is kept.
+func confluentAssignmentStrategy(groupBalancers []string, rack string) (string, error) {
+	strategies := make([]string, 0, len(groupBalancers))
+	cooperative := false
+	for _, groupBalancer := range groupBalancers {
+		var strategy string
+		switch groupBalancer {
+		case groupBalancerRange:
+			strategy = "range"
+		case groupBalancerRoundRobin:
+			strategy = "roundrobin"
+		case groupBalancerCooperativeSticky:
+			strategy = "cooperative-sticky"
+			cooperative = true
+		case groupBalancerRackAffinity:
+			if rack == "" {
+				return "", newInvalidConfigError("reader config", errRackRequired)
+			}
+			continue
+		default:
+			return "", newInvalidConfigError(
+				"reader config",
+				fmt.Errorf("%w: %q", errUnknownGroupBalancer, groupBalancer),
+			)
+		}
+
+		if !slices.Contains(strategies, strategy) {
+			strategies = append(strategies, strategy)
+		}
+	}
+
+	// librdkafka rejects mixing the cooperative protocol with eager assignors.
+	if cooperative && len(strategies) > 1 {
+		return "", newInvalidConfigError("reader config", errGroupBalancersMixed)
+	}
+
+	return strings.Join(strategies, ","), nil
+}
+
+// confluentIsolationLevel maps the ISOLATION_LEVEL_* constants to
+// librdkafka's "isolation.level". An empty level keeps librdkafka's default,
+// read_committed.
+func confluentIsolationLevel(isolationLevel string) (string, error) {
+	switch isolationLevel {
+	case "":
+		return "", nil
+	case isolationLevelReadUncommitted:
+		return confluentIsolationReadUncommitted, nil
+	case isolationLevelReadCommitted:
+		return confluentIsolationReadCommitted, nil
+	default:
+		return "", newInvalidConfigError(
+			"reader config",
+			fmt.Errorf("%w, got %q", errIsolationLevelInvalid, isolationLevel),
+		)
+	}
+}
+
+// effectiveIsolationLevel returns the ISOLATION_LEVEL_* constant matching the
+// "isolation.level" a consumer was created with.
+func effectiveIsolationLevel(config ckafka.ConfigMap) string {
+	if compatibilityConfigString(config, "isolation.level") == confluentIsolationReadUncommitted {
+		return isolationLevelReadUncommitted
+	}
+
+	return isolationLevelReadCommitted
+}
+
+// confluentTransactionalRequiredAcks resolves the acks setting for a transactional
+// producer. librdkafka requires acks=all for idempotent delivery, so an unset
+// requiredAcks defaults to -1 and any other explicit value, including 0, is
+// rejected.
+func confluentTransactionalRequiredAcks(requiredAcks int, set bool) (int, error) {
+	if requiredAcks == -1 || (requiredAcks == 0 && !set) {
+		return -1, nil
+	}
+
+	return 0, newInvalidConfigError("writer config", errTransactionalRequiredAcksInvalid)
+}
+
 func confluentOffset(startOffset string, explicitOffset int64) (ckafka.Offset, error) {
 	if explicitOffset > 0 {
 		return ckafka.Offset(explicitOffset), nil
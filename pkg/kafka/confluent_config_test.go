@@ -318,6 +318,19 @@ func TestReaderConfigToConfluentConfigMap_Table(t *testing.T) {
 		CommitInterval:    5 * time.Second,
 	}
 
+	t.Run("commit interval enables auto commit", func(t *testing.T) {
+		t.Parallel()
+		rc := base
+		cfg, err := readerConfigToConfluentConfigMap(&rc)
+		require.NoError(t, err)
+		assert.Equal(t, true, cfg["enable.auto.commit"])
+		assert.Equal(t, 5000, cfg["auto.commit.interval.ms"])
+
+		rc.CommitInterval = 0
+		cfg, err = readerConfigToConfluentConfigMap(&rc)
+		require.NoError(t, err)
+		assert.Equal(t, false, cfg["enable.auto.commit"])
+	})
 	t.Run("first offset reset", func(t *testing.T) {
 		t.Parallel()
 		rc := base
@@ -358,6 +371,18 @@ func TestReaderConfigToConfluentConfigMap_Table(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "earliest", cfg["auto.offset.reset"])
 	})
+	t.Run("group instance id", func(t *testing.T) {
+		t.Parallel()
+		rc := base
+		rc.GroupInstanceID = "member-1"
+		cfg, err := readerConfigToConfluentConfigMap(&rc)
+		require.NoError(t, err)
+		assert.Equal(t, "member-1", cfg["group.instance.id"])
+
+		rc.GroupID = ""
+		_, err = readerConfigToConfluentConfigMap(&rc)
+		require.ErrorIs(t, err, errGroupInstanceIDRequiresGroup)
+	})
 	t.Run("nil reader config", func(t *testing.T) {
 		t.Parallel()
 		_, err := readerConfigToConfluentConfigMap(nil)
@@ -493,3 +518,157 @@ func TestConfluentMetadataTimeoutMs(t *testing.T) {
 		assert.LessOrEqual(t, ms, 750)
 	})
 }
+
+func TestApplyConfluentRawConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("raw overrides typed options", func(t *testing.T) {
+		t.Parallel()
+		cm, err := writerConfigToConfluentConfigMap(&WriterConfig{
+			Brokers:   []string{"localhost:9092"},
+			BatchSize: 10,
+			Config: map[string]any{
+				"batch.num.messages":         float64(500),
+				"queue.buffering.max.kbytes": float64(2048),
+				"socket.keepalive.enable":    true,
+				"compression.type":           "zstd",
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 500, cm["batch.num.messages"])
+		assert.Equal(t, 2048, cm["queue.buffering.max.kbytes"])
+		assert.Equal(t, true, cm["socket.keepalive.enable"])
+		assert.Equal(t, "zstd", cm["compression.type"])
+		assert.Equal(t, false, cm["go.delivery.reports"])
+	})
+
+	t.Run("denied keys", func(t *testing.T) {
+		t.Parallel()
+		_, err := writerConfigToConfluentConfigMap(&WriterConfig{
+			Brokers: []string{"localhost:9092"},
+			Config:  map[string]any{"go.delivery.reports": true},
+		})
+		require.ErrorIs(t, err, errConfigKeyDenied)
+
+		_, err = readerConfigToConfluentConfigMap(&ReaderConfig{
+			Brokers: []string{"localhost:9092"},
+			Config:  map[string]any{"group.id": "raw-group"},
+		})
+		require.ErrorIs(t, err, errConfigKeyDenied)
+		assert.Contains(t, err.Error(), `use "groupId" instead`)
+
+		_, err = readerConfigToConfluentConfigMap(&ReaderConfig{
+			Brokers: []string{"localhost:9092"},
+			GroupID: "group",
+			Config:  map[string]any{"enable.auto.commit": true},
+		})
+		require.ErrorIs(t, err, errConfigKeyDenied)
+		assert.Contains(t, err.Error(), `use "commitInterval" or commitOffsets() instead`)
+	})
+
+	t.Run("unknown keys pass unless strict", func(t *testing.T) {
+		t.Parallel()
+		cm, err := connectionConfigToConfluentConfigMap(&ConnectionConfig{
+			Address: "localhost:9092",
+			Config:  map[string]any{"socket.keepalive.enabel": true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, true, cm["socket.keepalive.enabel"])
+
+		_, err = connectionConfigToConfluentConfigMap(&ConnectionConfig{
+			Address:      "localhost:9092",
+			Config:       map[string]any{"socket.keepalive.enabel": true},
+			StrictConfig: true,
+		})
+		require.ErrorIs(t, err, errConfigKeyUnknown)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		t.Parallel()
+		_, err := readerConfigToConfluentConfigMap(&ReaderConfig{
+			Brokers: []string{"localhost:9092"},
+			Config:  map[string]any{"fetch.max.bytes": map[string]any{"nested": 1}},
+		})
+		require.ErrorIs(t, err, errConfigValueInvalid)
+
+		value, err := confluentConfigValue(0.5)
+		require.NoError(t, err)
+		assert.Equal(t, "0.5", value)
+	})
+}
+
+func TestRedactConfluentConfig(t *testing.T) {
+	t.Parallel()
+	redacted := redactConfluentConfig(ckafka.ConfigMap{
+		"sasl.username":                  "user",
+		"sasl.password":                  "secret",
+		"ssl.key.pem":                    "pem",
+		"sasl.oauthbearer.client.secret": "secret",
+	})
+	assert.Equal(t, "user", redacted["sasl.username"])
+	assert.Equal(t, redactedConfigValue, redacted["sasl.password"])
+	assert.Equal(t, redactedConfigValue, redacted["ssl.key.pem"])
+	assert.Equal(t, redactedConfigValue, redacted["sasl.oauthbearer.client.secret"])
+}
+
+func TestConfluentIsolationLevel(t *testing.T) {
+	t.Parallel()
+
+	cm, err := readerConfigToConfluentConfigMap(&ReaderConfig{
+		Brokers:        []string{"localhost:9092"},
+		IsolationLevel: isolationLevelReadUncommitted,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "read_uncommitted", cm["isolation.level"])
+	assert.Equal(t, isolationLevelReadUncommitted, effectiveIsolationLevel(cm))
+
+	cm, err = readerConfigToConfluentConfigMap(&ReaderConfig{Brokers: []string{"localhost:9092"}})
+	require.NoError(t, err)
+	assert.NotContains(t, cm, "isolation.level")
+	assert.Equal(t, isolationLevelReadCommitted, effectiveIsolationLevel(cm))
+
+	_, err = readerConfigToConfluentConfigMap(&ReaderConfig{
+		Brokers:        []string{"localhost:9092"},
+		IsolationLevel: "read_committed",
+	})
+	require.ErrorIs(t, err, errIsolationLevelInvalid)
+}
+
+func TestConfluentAssignmentStrategy(t *testing.T) {
+	t.Parallel()
+
+	cm, err := readerConfigToConfluentConfigMap(&ReaderConfig{
+		Brokers:        []string{"localhost:9092"},
+		GroupID:        "group",
+		GroupBalancers: []string{groupBalancerRoundRobin, groupBalancerRackAffinity, groupBalancerRange},
+		Rack:           "eu-west-1a",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "roundrobin,range", cm["partition.assignment.strategy"])
+	assert.Equal(t, "eu-west-1a", cm["client.rack"])
+
+	cm, err = readerConfigToConfluentConfigMap(&ReaderConfig{
+		Brokers:        []string{"localhost:9092"},
+		GroupBalancers: []string{groupBalancerCooperativeSticky},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cooperative-sticky", cm["partition.assignment.strategy"])
+	assert.NotContains(t, cm, "client.rack")
+
+	cm, err = readerConfigToConfluentConfigMap(&ReaderConfig{Brokers: []string{"localhost:9092"}})
+	require.NoError(t, err)
+	assert.NotContains(t, cm, "partition.assignment.strategy")
+
+	cases := map[string]struct {
+		balancers []string
+		expected  error
+	}{
+		"rack affinity without rack": {[]string{groupBalancerRackAffinity}, errRackRequired},
+		"cooperative with eager":     {[]string{groupBalancerCooperativeSticky, groupBalancerRange}, errGroupBalancersMixed},
+		"unknown":                    {[]string{"sticky"}, errUnknownGroupBalancer},
+	}
+	for name, tc := range cases {
+		_, err := confluentAssignmentStrategy(tc.balancers, "")
+		require.ErrorIs(t, err, tc.expected, name)
+	}
+}
@@ -0,0 +1,240 @@
+package kafka
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const redactedConfigValue = "[REDACTED]"
+
+// confluentConfigDenied lists raw config keys that xk6-kafka manages itself,
+// with what to use instead. Any "go." key is denied as well, since those
+// switch how the Go client hands events to the runtime.
+var confluentConfigDenied = map[string]map[string]string{
+	"writer config": {
+		"transactional.id": `"transactionalId"`,
+	},
+	"reader config": {
+		"group.id":          `"groupId"`,
+		"group.instance.id": `"groupInstanceId"`,
+		// Auto commit is on for group consumers with a commitInterval, and
+		// off otherwise.
+		"enable.auto.commit": `"commitInterval" or commitOffsets()`,
+	},
+	"connection config": {},
+}
+
+// confluentConfigKnown holds the librdkafka properties accepted without a
+// warning. Keys outside of it are still passed to librdkafka, which rejects
+// properties it does not know.
+var confluentConfigKnown = map[string]struct{}{
+	"acks":                                    {},
+	"allow.auto.create.topics":                {},
+	"api.version.fallback.ms":                 {},
+	"api.version.request":                     {},
+	"api.version.request.timeout.ms":          {},
+	"auto.commit.interval.ms":                 {},
+	"auto.offset.reset":                       {},
+	"batch.num.messages":                      {},
+	"batch.size":                              {},
+	"bootstrap.servers":                       {},
+	"broker.address.family":                   {},
+	"broker.address.ttl":                      {},
+	"broker.version.fallback":                 {},
+	"check.crcs":                              {},
+	"client.dns.lookup":                       {},
+	"client.id":                               {},
+	"client.rack":                             {},
+	"compression.codec":                       {},
+	"compression.level":                       {},
+	"compression.type":                        {},
+	"connections.max.idle.ms":                 {},
+	"coordinator.query.interval.ms":           {},
+	"debug":                                   {},
+	"delivery.timeout.ms":                     {},
+	"enable.auto.offset.store":                {},
+	"enable.gapless.guarantee":                {},
+	"enable.idempotence":                      {},
+	"enable.metrics.push":                     {},
+	"enable.partition.eof":                    {},
+	"enable.random.seed":                      {},
+	"enable.sasl.oauthbearer.unsecure.jwt":    {},
+	"enable.ssl.certificate.verification":     {},
+	"fetch.error.backoff.ms":                  {},
+	"fetch.max.bytes":                         {},
+	"fetch.message.max.bytes":                 {},
+	"fetch.min.bytes":                         {},
+	"fetch.queue.backoff.ms":                  {},
+	"fetch.wait.max.ms":                       {},
+	"group.instance.id":                       {},
+	"group.protocol":                          {},
+	"group.remote.assignor":                   {},
+	"heartbeat.interval.ms":                   {},
+	"isolation.level":                         {},
+	"linger.ms":                               {},
+	"log.connection.close":                    {},
+	"log.queue":                               {},
+	"log.thread.name":                         {},
+	"log_level":                               {},
+	"max.in.flight":                           {},
+	"max.in.flight.requests.per.connection":   {},
+	"max.partition.fetch.bytes":               {},
+	"max.poll.interval.ms":                    {},
+	"message.copy.max.bytes":                  {},
+	"message.max.bytes":                       {},
+	"message.send.max.retries":                {},
+	"message.timeout.ms":                      {},
+	"metadata.broker.list":                    {},
+	"metadata.max.age.ms":                     {},
+	"metadata.recovery.strategy":              {},
+	"partition.assignment.strategy":           {},
+	"partitioner":                             {},
+	"queue.buffering.backpressure.threshold":  {},
+	"queue.buffering.max.kbytes":              {},
+	"queue.buffering.max.messages":            {},
+	"queue.buffering.max.ms":                  {},
+	"queued.max.messages.kbytes":              {},
+	"queued.min.messages":                     {},
+	"receive.message.max.bytes":               {},
+	"reconnect.backoff.jitter.ms":             {},
+	"reconnect.backoff.max.ms":                {},
+	"reconnect.backoff.ms":                    {},
+	"request.required.acks":                   {},
+	"request.timeout.ms":                      {},
+	"retries":                                 {},
+	"retry.backoff.max.ms":                    {},
+	"retry.backoff.ms":                        {},
+	"sasl.kerberos.keytab":                    {},
+	"sasl.kerberos.kinit.cmd":                 {},
+	"sasl.kerberos.min.time.before.relogin":   {},
+	"sasl.kerberos.principal":                 {},
+	"sasl.kerberos.service.name":              {},
+	"sasl.mechanism":                          {},
+	"sasl.mechanisms":                         {},
+	"sasl.oauthbearer.client.id":              {},
+	"sasl.oauthbearer.client.secret":          {},
+	"sasl.oauthbearer.config":                 {},
+	"sasl.oauthbearer.extensions":             {},
+	"sasl.oauthbearer.method":                 {},
+	"sasl.oauthbearer.scope":                  {},
+	"sasl.oauthbearer.token.endpoint.url":     {},
+	"sasl.password":                           {},
+	"sasl.username":                           {},
+	"security.protocol":                       {},
+	"session.timeout.ms":                      {},
+	"socket.blocking.max.ms":                  {},
+	"socket.connection.setup.timeout.ms":      {},
+	"socket.keepalive.enable":                 {},
+	"socket.max.fails":                        {},
+	"socket.nagle.disable":                    {},
+	"socket.receive.buffer.bytes":             {},
+	"socket.send.buffer.bytes":                {},
+	"socket.timeout.ms":                       {},
+	"ssl.ca.certificate.stores":               {},
+	"ssl.ca.location":                         {},
+	"ssl.ca.pem":                              {},
+	"ssl.certificate.location":                {},
+	"ssl.certificate.pem":                     {},
+	"ssl.cipher.suites":                       {},
+	"ssl.crl.location":                        {},
+	"ssl.curves.list":                         {},
+	"ssl.endpoint.identification.algorithm":   {},
+	"ssl.engine.id":                           {},
+	"ssl.engine.location":                     {},
+	"ssl.key.location":                        {},
+	"ssl.key.password":                        {},
+	"ssl.key.pem":                             {},
+	"ssl.keystore.location":                   {},
+	"ssl.keystore.password":                   {},
+	"ssl.providers":                           {},
+	"ssl.sigalgs.list":                        {},
+	"statistics.interval.ms":                  {},
+	"sticky.partitioning.linger.ms":           {},
+	"topic.metadata.propagation.max.ms":       {},
+	"topic.metadata.refresh.fast.interval.ms": {},
+	"topic.metadata.refresh.interval.ms":      {},
+	"topic.metadata.refresh.sparse":           {},
+	"transaction.timeout.ms":                  {},
+}
+
+// confluentConfigSensitive marks keys whose values are never logged.
+var confluentConfigSensitive = []string{"password", "secret", "ssl.key.pem", "sasl.oauthbearer.config"}
+
+// applyConfluentRawConfig merges the raw librdkafka properties of a client
+// config over the typed options, so raw values take precedence. Unknown keys
+// are logged, or rejected when strict is set.
+func applyConfluentRawConfig(config ckafka.ConfigMap, raw map[string]any, strict bool, component string) error {
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if strings.HasPrefix(key, "go.") {
+			return newInvalidConfigError(component, fmt.Errorf("%w: %q", errConfigKeyDenied, key))
+		}
+		if replacement, ok := confluentConfigDenied[component][key]; ok {
+			return newInvalidConfigError(
+				component,
+				fmt.Errorf("%w: %q, use %s instead", errConfigKeyDenied, key, replacement),
+			)
+		}
+
+		if _, ok := confluentConfigKnown[key]; !ok {
+			if strict {
+				return newInvalidConfigError(component, fmt.Errorf("%w: %q", errConfigKeyUnknown, key))
+			}
+			logger.WithField("key", key).Warnf("Unknown librdkafka property in %s, passing it through.", component)
+		}
+
+		value, err := confluentConfigValue(raw[key])
+		if err != nil {
+			return newInvalidConfigError(component, fmt.Errorf("%q: %w", key, err))
+		}
+		if err := setConfluentConfigValue(config, key, value); err != nil {
+			return newInvalidConfigError(component, err)
+		}
+	}
+
+	logger.WithField("config", redactConfluentConfig(config)).Debugf("Effective librdkafka %s.", component)
+
+	return nil
+}
+
+// confluentConfigValue converts a JS value to one of the types ConfigMap accepts.
+func confluentConfigValue(value any) (ckafka.ConfigValue, error) {
+	switch v := value.(type) {
+	case string, bool, int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+			return int(v), nil
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return nil, fmt.Errorf("%w, got %T", errConfigValueInvalid, value)
+	}
+}
+
+func redactConfluentConfig(config ckafka.ConfigMap) map[string]any {
+	redacted := make(map[string]any, len(config))
+	for key, value := range config {
+		redacted[key] = value
+		for _, sensitive := range confluentConfigSensitive {
+			if strings.Contains(key, sensitive) {
+				redacted[key] = redactedConfigValue
+				break
+			}
+		}
+	}
+
+	return redacted
+}
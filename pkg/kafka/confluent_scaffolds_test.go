@@ -160,3 +160,32 @@ func TestConfluentScaffoldsWithMockCluster(t *testing.T) {
 
 	require.NoError(t, consumer.CommitOffsets(ctx))
 }
+
+func TestConfluentCooperativeStickyGroupWithMockCluster(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "cooperative-sticky-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 2, 1))
+
+	// Only a single member is tested: the mock cluster's coordinator does not
+	// finish incremental rebalances between several members reliably.
+	consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:        []string{mockCluster.BootstrapServers()},
+		GroupID:        "cooperative-sticky-group",
+		GroupTopics:    []string{topicName},
+		GroupBalancers: []string{groupBalancerCooperativeSticky},
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, consumer.Close())
+	}()
+
+	require.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, _ = consumer.Consume(ctx, 1)
+		return consumer.Stats().Assignments == 2
+	}, 20*time.Second, 10*time.Millisecond)
+}
@@ -12,6 +12,14 @@ import (
 
 type ConsumerStats struct {
 	Assignments int
+	// IsolationLevel is the effective ISOLATION_LEVEL_* of the consumer.
+	IsolationLevel string
+	// Paused lists the assigned partitions that are paused.
+	Paused []TopicPartition
+	// GroupInstanceID is the static member identity of the consumer.
+	GroupInstanceID string
+	// MemberID is assigned by the group coordinator when the consumer joins.
+	MemberID string
 }
 
 const (
@@ -23,22 +31,49 @@ type Consumer struct {
 	client      *ckafka.Consumer
 	saslContext SASLContext
 	config      ckafka.ConfigMap
+	startOffset string
+	startTime   time.Time
+	group       bool
+	stats       *clientStats
+	onRebalance RebalanceHandler
+	rebalances  *rebalanceTracker
+	commits     *commitTracker
+	// keyDeserializer and valueDeserializer are nil for keys and values
+	// returned as bytes.
+	keyDeserializer   *messageDeserializer
+	valueDeserializer *messageDeserializer
+	// startTimeApplied holds the partitions a group consumer has already
+	// started from startTime.
+	startTimeApplied map[consumerMetricKey]struct{}
+
+	mu sync.Mutex
+	// topic is the single topic seek and position use, which Assign and
+	// IncrementalAssign change.
 	topic       string
-
-	mu          sync.Mutex
 	closeCond   *sync.Cond
 	activeCalls int
 	closing     bool
+	inRebalance bool
+	stream      *messageStream
+	paused      map[consumerMetricKey]struct{}
+
+	// lags holds the lag sampled every ReadLagInterval, until reported.
+	lagMu   sync.Mutex
+	lags    map[consumerMetricKey]int64
+	lagDone chan struct{}
 }
 
 var errConsumerClosing = errors.New("consumer is closing")
 
 func NewConsumerFromReaderConfig(readerConfig *ReaderConfig) (*Consumer, error) {
+	if readerConfig == nil {
+		return nil, newMissingConfigError("reader config")
+	}
 	config, err := readerConfigToConfluentConfigMap(readerConfig)
 	if err != nil {
 		return nil, err
 	}
-	if readerConfig != nil && readerConfig.GroupID == "" {
+	if readerConfig.GroupID == "" {
 		if err := setConfluentConfigValue(
 			config,
 			"group.id",
@@ -56,8 +91,20 @@ func NewConsumerFromReaderConfig(readerConfig *ReaderConfig) (*Consumer, error)
 		return nil, err
 	}
 
+	keyDeserializer, err := newMessageDeserializer(Key, readerConfig.KeyDeserializer)
+	if err != nil {
+		return nil, err
+	}
+	valueDeserializer, err := newMessageDeserializer(Value, readerConfig.ValueDeserializer)
+	if err != nil {
+		keyDeserializer.close()
+		return nil, err
+	}
+
 	client, err := ckafka.NewConsumer(&config)
 	if err != nil {
+		keyDeserializer.close()
+		valueDeserializer.close()
 		return nil, NewXk6KafkaError(failedCreateConsumer, "Failed to create consumer.", err)
 	}
 
@@ -65,58 +112,85 @@ func NewConsumerFromReaderConfig(readerConfig *ReaderConfig) (*Consumer, error)
 		client:      client,
 		saslContext: saslContext,
 		config:      cloneConfluentConfigMap(config),
+		stats:       &clientStats{},
+		rebalances:  &rebalanceTracker{},
+		commits:     &commitTracker{},
+		paused:      make(map[consumerMetricKey]struct{}),
+
+		keyDeserializer:   keyDeserializer,
+		valueDeserializer: valueDeserializer,
 	}
 	consumer.closeCond = sync.NewCond(&consumer.mu)
-
-	if readerConfig == nil {
-		return nil, newMissingConfigError("reader config")
+	// closeOnError releases the client and the Schema Registry clients of the
+	// deserializers when the consumer cannot be set up.
+	closeOnError := func() {
+		_ = client.Close()
+		keyDeserializer.close()
+		valueDeserializer.close()
 	}
+	consumer.onRebalance = readerConfig.OnRebalance
+
+	consumer.startOffset = readerConfig.StartOffset
+	consumer.startTime = readerConfig.StartTime
 
 	switch {
 	case readerConfig.GroupID != "":
+		if len(readerConfig.Assignments) > 0 {
+			closeOnError()
+			return nil, newInvalidConfigError("reader config", errAssignmentRequiresNoGroup)
+		}
 		topics := append([]string(nil), readerConfig.GroupTopics...)
 		if len(topics) == 0 && readerConfig.Topic != "" {
 			topics = []string{readerConfig.Topic}
 		}
 		if len(topics) == 0 {
-			_ = client.Close()
+			closeOnError()
 			return nil, newInvalidConfigError("reader config", errGroupTopicsMustNotBeEmpty)
 		}
 		if len(topics) == 1 {
 			consumer.topic = topics[0]
 		}
-		if err := client.SubscribeTopics(topics, nil); err != nil {
-			_ = client.Close()
+		consumer.group = true
+		consumer.startTimeApplied = make(map[consumerMetricKey]struct{})
+		consumer.rebalances.start(time.Now())
+		if err := client.SubscribeTopics(topics, consumer.rebalance); err != nil {
+			closeOnError()
 			return nil, NewXk6KafkaError(failedCreateConsumer, "Failed to subscribe consumer.", err)
 		}
 	default:
-		if readerConfig.Topic == "" {
-			_ = client.Close()
-			return nil, newInvalidConfigError("reader config", errTopicMustNotBeEmpty)
+		assignments := readerConfig.Assignments
+		if len(assignments) == 0 {
+			if readerConfig.Topic == "" {
+				closeOnError()
+				return nil, newInvalidConfigError("reader config", errTopicMustNotBeEmpty)
+			}
+			assignments = []TopicPartition{{
+				Topic:     readerConfig.Topic,
+				Partition: readerConfig.Partition,
+				Offset:    readerConfig.Offset,
+			}}
 		}
 
-		offset, err := confluentOffset(readerConfig.StartOffset, readerConfig.Offset)
-		if err != nil {
-			_ = client.Close()
-			return nil, err
+		partitions, err := confluentTopicPartitions(assignments, readerConfig.StartOffset, "reader config")
+		if err == nil {
+			err = consumer.applyStartTime(client, assignments, partitions)
 		}
-
-		consumer.topic = readerConfig.Topic
-		partition, err := consumerPartition(readerConfig.Partition, "reader config")
 		if err != nil {
-			_ = client.Close()
+			closeOnError()
 			return nil, err
 		}
-		if err := client.Assign([]ckafka.TopicPartition{{
-			Topic:     &consumer.topic,
-			Partition: partition,
-			Offset:    offset,
-		}}); err != nil {
-			_ = client.Close()
+
+		consumer.topic = singleTopic(assignments)
+		if err := client.Assign(partitions); err != nil {
+			closeOnError()
 			return nil, NewXk6KafkaError(failedCreateConsumer, "Failed to assign consumer.", err)
 		}
 	}
 
+	if readerConfig.ReadLagInterval > 0 {
+		consumer.startLagSampler(readerConfig.ReadLagInterval)
+	}
+
 	return consumer, nil
 }
 
@@ -133,6 +207,10 @@ func (c *Consumer) Consume(ctx context.Context, limit int) ([]Message, error) {
 	}
 	defer c.endOperation()
 
+	if c.started() {
+		return nil, consumerReadError(errConsumerStarted)
+	}
+
 	if limit <= 0 {
 		limit = 1
 	}
@@ -157,7 +235,15 @@ func (c *Consumer) Consume(ctx context.Context, limit int) ([]Message, error) {
 		}
 
 		if msg != nil {
-			messages = append(messages, confluentMessageToMessage(msg))
+			message := confluentMessageToMessage(msg)
+			setHighWaterMark(client, &message)
+			keep, err := c.decode(&message)
+			if err != nil {
+				return messages, err
+			}
+			if keep {
+				messages = append(messages, message)
+			}
 		}
 	}
 
@@ -200,7 +286,8 @@ func (c *Consumer) Seek(partition int, offset int64) error {
 	if c == nil {
 		return newMissingConfigError("consumer")
 	}
-	if c.topic == "" {
+	topic := c.assignedTopic()
+	if topic == "" {
 		return newInvalidConfigError("consumer", errSeekRequiresSingleConfiguredTopic)
 	}
 	client, err := c.beginOperation()
@@ -218,7 +305,7 @@ func (c *Consumer) Seek(partition int, offset int64) error {
 	}
 
 	err = client.Seek(ckafka.TopicPartition{
-		Topic:     &c.topic,
+		Topic:     &topic,
 		Partition: partitionValue,
 		Offset:    ckafka.Offset(offset),
 	}, -1)
@@ -233,7 +320,8 @@ func (c *Consumer) Position(partition int) (int64, error) {
 	if c == nil {
 		return 0, newMissingConfigError("consumer")
 	}
-	if c.topic == "" {
+	topic := c.assignedTopic()
+	if topic == "" {
 		return 0, newInvalidConfigError("consumer", errPositionRequiresSingleConfiguredTopic)
 	}
 	client, err := c.beginOperation()
@@ -251,7 +339,7 @@ func (c *Consumer) Position(partition int) (int64, error) {
 	}
 
 	positions, err := client.Position([]ckafka.TopicPartition{{
-		Topic:     &c.topic,
+		Topic:     &topic,
 		Partition: partitionValue,
 	}})
 	if err != nil {
@@ -264,7 +352,9 @@ func (c *Consumer) Position(partition int) (int64, error) {
 	return int64(positions[0].Offset), nil
 }
 
-func (c *Consumer) CommitOffsets(ctx context.Context) error {
+// CommitOffsets synchronously commits the given offsets, or the offsets
+// consumed so far when there are none.
+func (c *Consumer) CommitOffsets(ctx context.Context, partitions ...TopicPartition) error {
 	if c == nil {
 		return newMissingConfigError("consumer")
 	}
@@ -282,7 +372,14 @@ func (c *Consumer) CommitOffsets(ctx context.Context) error {
 		return NewXk6KafkaError(failedCommitConsumer, "Consumer context cancelled.", err)
 	}
 
-	if _, err := client.Commit(); err != nil {
+	offsets, err := commitTopicPartitions(partitions, "commit offsets")
+	if err != nil {
+		return err
+	}
+
+	err = commitConsumerOffsets(client, offsets)
+	c.commits.record(commitModeSync, err)
+	if err != nil {
 		return NewXk6KafkaError(failedCommitConsumer, "Failed to commit consumer offsets.", err)
 	}
 
@@ -299,8 +396,17 @@ func (c *Consumer) Close() error {
 		c.mu.Unlock()
 		return nil
 	}
+	// Closing waits for the consume call that runs the handler to return.
+	if c.inRebalance {
+		c.mu.Unlock()
+		return NewXk6KafkaError(failedCreateConsumer, "Failed to close consumer.", errCloseInRebalanceHandler)
+	}
 
 	c.closing = true
+	c.abortStream()
+	if c.lagDone != nil {
+		close(c.lagDone)
+	}
 	for c.activeCalls > 0 {
 		c.closeCond.Wait()
 	}
@@ -309,6 +415,8 @@ func (c *Consumer) Close() error {
 	c.client = nil
 	c.mu.Unlock()
 
+	c.keyDeserializer.close()
+	c.valueDeserializer.close()
 	if err := client.Close(); err != nil {
 		return NewXk6KafkaError(failedCreateConsumer, "Failed to close consumer.", err)
 	}
@@ -325,12 +433,20 @@ func (c *Consumer) Stats() ConsumerStats {
 	}
 	defer c.endOperation()
 
+	stats := ConsumerStats{IsolationLevel: effectiveIsolationLevel(c.config)}
+	if groupInstanceID, ok := c.config["group.instance.id"].(string); ok {
+		stats.GroupInstanceID = groupInstanceID
+	}
+
 	assignments, err := client.Assignment()
 	if err != nil {
-		return ConsumerStats{}
+		return stats
 	}
+	stats.Assignments = len(assignments)
+	stats.Paused = c.pausedPartitions(assignments)
+	stats.MemberID = c.memberID(context.Background(), client, assignments)
 
-	return ConsumerStats{Assignments: len(assignments)}
+	return stats
 }
 
 func (c *Consumer) beginOperation() (*ckafka.Consumer, error) {
@@ -376,6 +492,15 @@ func (c *Consumer) endOperation() {
 	}
 }
 
+// assignedTopic returns the topic seek and position use, or "" when the
+// consumer reads from several topics.
+func (c *Consumer) assignedTopic() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.topic
+}
+
 func (c *Consumer) closeRequested() bool {
 	if c == nil {
 		return false
@@ -452,6 +577,9 @@ func (c *Consumer) poll(
 	timeoutMs int,
 ) (ckafka.Event, error) {
 	event := client.Poll(timeoutMs)
+	if err := c.rebalances.takeError(); err != nil {
+		return nil, err
+	}
 
 	switch e := event.(type) {
 	case *ckafka.Message:
@@ -464,6 +592,10 @@ func (c *Consumer) poll(
 		if err != nil {
 			return event, err
 		}
+	case *ckafka.Stats:
+		c.stats.update(e.String())
+	case ckafka.OffsetsCommitted:
+		c.commitAutoResult(e)
 	case ckafka.Error:
 		return nil, e
 	default:
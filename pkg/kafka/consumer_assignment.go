@@ -0,0 +1,171 @@
+package kafka
+
+import (
+	"errors"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Assign replaces the partitions a consumer without a group reads from.
+// Offsets that are not positive start the partition from startTime, if set,
+// or from startOffset.
+func (c *Consumer) Assign(partitions []TopicPartition) error {
+	return c.changeAssignment("Failed to assign partitions.", func(client *ckafka.Consumer) error {
+		confluentPartitions, err := confluentTopicPartitions(partitions, c.startOffset, "assign")
+		if err != nil {
+			return err
+		}
+		if err := c.applyStartTime(client, partitions, confluentPartitions); err != nil {
+			return err
+		}
+		if err := client.Assign(confluentPartitions); err != nil {
+			return err
+		}
+		if err := c.pauseAgain(client, confluentPartitions); err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		c.topic = singleTopic(partitions)
+		c.mu.Unlock()
+		return nil
+	})
+}
+
+// IncrementalAssign adds partitions to the current assignment.
+func (c *Consumer) IncrementalAssign(partitions []TopicPartition) error {
+	return c.changeAssignment("Failed to assign partitions.", func(client *ckafka.Consumer) error {
+		confluentPartitions, err := confluentTopicPartitions(partitions, c.startOffset, "incremental assign")
+		if err != nil {
+			return err
+		}
+		if err := c.applyStartTime(client, partitions, confluentPartitions); err != nil {
+			return err
+		}
+		if err := client.IncrementalAssign(confluentPartitions); err != nil {
+			return err
+		}
+		if err := c.pauseAgain(client, confluentPartitions); err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		if singleTopic(partitions) != c.topic {
+			c.topic = ""
+		}
+		c.mu.Unlock()
+		return nil
+	})
+}
+
+// Unassign removes partitions from the current assignment, or every
+// partition when none are given.
+func (c *Consumer) Unassign(partitions []TopicPartition) error {
+	return c.changeAssignment("Failed to unassign partitions.", func(client *ckafka.Consumer) error {
+		if len(partitions) == 0 {
+			if err := client.Unassign(); err != nil {
+				return err
+			}
+
+			c.mu.Lock()
+			c.topic = ""
+			c.mu.Unlock()
+			return nil
+		}
+
+		confluentPartitions, err := confluentTopicPartitions(partitions, c.startOffset, "unassign")
+		if err != nil {
+			return err
+		}
+		if err := client.IncrementalUnassign(confluentPartitions); err != nil {
+			return err
+		}
+
+		// The remaining partitions may share a topic again.
+		assignment, err := client.Assignment()
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.topic = singleTopic(topicPartitionsFromConfluent(assignment))
+		c.mu.Unlock()
+		return nil
+	})
+}
+
+func (c *Consumer) changeAssignment(message string, change func(client *ckafka.Consumer) error) error {
+	if c == nil {
+		return newMissingConfigError("consumer")
+	}
+	// The group coordinator owns the assignment of group consumers.
+	if c.group {
+		return newInvalidConfigError("consumer", errAssignmentRequiresNoGroup)
+	}
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return NewXk6KafkaError(failedAssignPartitions, message, err)
+		}
+		return err
+	}
+	defer c.endOperation()
+
+	if err := change(client); err != nil {
+		var xk6KafkaErr *Xk6KafkaError
+		if errors.As(err, &xk6KafkaErr) {
+			return err
+		}
+		return NewXk6KafkaError(failedAssignPartitions, message, err)
+	}
+
+	return nil
+}
+
+// confluentTopicPartitions validates partitions and resolves their offsets
+// like the reader config's offset and startOffset.
+func confluentTopicPartitions(
+	partitions []TopicPartition,
+	startOffset string,
+	component string,
+) ([]ckafka.TopicPartition, error) {
+	converted := make([]ckafka.TopicPartition, 0, len(partitions))
+	for _, partition := range partitions {
+		if partition.Topic == "" {
+			return nil, newInvalidConfigError(component, errTopicMustNotBeEmpty)
+		}
+		partitionValue, err := consumerPartition(partition.Partition, component)
+		if err != nil {
+			return nil, err
+		}
+		offset, err := confluentOffset(startOffset, partition.Offset)
+		if err != nil {
+			return nil, err
+		}
+
+		topic := partition.Topic
+		converted = append(converted, ckafka.TopicPartition{
+			Topic:     &topic,
+			Partition: partitionValue,
+			Offset:    offset,
+		})
+	}
+
+	return converted, nil
+}
+
+// singleTopic returns the topic shared by all partitions, or "" when they
+// span several topics. seek and position need a single topic.
+func singleTopic(partitions []TopicPartition) string {
+	if len(partitions) == 0 {
+		return ""
+	}
+
+	topic := partitions[0].Topic
+	for _, partition := range partitions[1:] {
+		if partition.Topic != topic {
+			return ""
+		}
+	}
+
+	return topic
+}
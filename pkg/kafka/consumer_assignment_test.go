@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfluentTopicPartitions(t *testing.T) {
+	t.Parallel()
+
+	partitions, err := confluentTopicPartitions([]TopicPartition{
+		{Topic: "orders", Partition: 0},
+		{Topic: "payments", Partition: 2, Offset: 7},
+	}, lastOffset, "assign")
+	require.NoError(t, err)
+	require.Len(t, partitions, 2)
+	assert.Equal(t, "orders", *partitions[0].Topic)
+	assert.Equal(t, ckafka.OffsetEnd, partitions[0].Offset)
+	assert.Equal(t, int32(2), partitions[1].Partition)
+	assert.Equal(t, ckafka.Offset(7), partitions[1].Offset)
+
+	_, err = confluentTopicPartitions([]TopicPartition{{Partition: 1}}, "", "assign")
+	assert.ErrorIs(t, err, errTopicMustNotBeEmpty)
+
+	assert.Equal(t, "orders", singleTopic([]TopicPartition{{Topic: "orders"}, {Topic: "orders", Partition: 1}}))
+	assert.Empty(t, singleTopic([]TopicPartition{{Topic: "orders"}, {Topic: "payments"}}))
+	assert.Empty(t, singleTopic(nil))
+}
+
+func TestConsumerRejectsAssignmentsWithGroup(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:     []string{"localhost:9092"},
+		GroupID:     "assignment-group",
+		GroupTopics: []string{"orders"},
+		Assignments: []TopicPartition{{Topic: "orders"}},
+	})
+	require.ErrorIs(t, err, errAssignmentRequiresNoGroup)
+}
+
+func TestConsumerClassAssignsPartitions(t *testing.T) {
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-assignment-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 3, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	for partition := range 3 {
+		require.NoError(t, producer.Produce(ctx, []Message{
+			{Partition: partition, partitionSet: true, Value: []byte("a")},
+			{Partition: partition, partitionSet: true, Value: []byte("b")},
+		}))
+	}
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+			"assignments": []map[string]any{
+				{"topic": topicName, "partition": 0},
+				{"topic": topicName, "partition": 2, "offset": 1},
+			},
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	consumePartitions := func(count int) []string {
+		messages := consume(sobek.FunctionCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": count})},
+		}).Export().([]map[string]any)
+
+		read := make([]string, 0, len(messages))
+		for _, message := range messages {
+			read = append(read, fmt.Sprintf("%d:%d", message["partition"], message["offset"]))
+		}
+		sort.Strings(read)
+		return read
+	}
+
+	assert.Equal(t, []string{"0:0", "0:1", "2:1"}, consumePartitions(3))
+
+	stats := consumer.Get("stats").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Equal(t, 2, stats(sobek.FunctionCall{}).Export().(map[string]any)["assignments"])
+
+	unassign := consumer.Get("unassign").Export().(func(sobek.FunctionCall) sobek.Value)
+	unassign(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 2}})},
+	})
+	incrementalAssign := consumer.Get("incrementalAssign").Export().(func(sobek.FunctionCall) sobek.Value)
+	incrementalAssign(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 1}})},
+	})
+	assert.Equal(t, []string{"1:0", "1:1"}, consumePartitions(2))
+	assert.Equal(t, 2, stats(sobek.FunctionCall{}).Export().(map[string]any)["assignments"])
+
+	assign := consumer.Get("assign").Export().(func(sobek.FunctionCall) sobek.Value)
+	assign(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 2}})},
+	})
+	assert.Equal(t, []string{"2:0", "2:1"}, consumePartitions(2))
+
+	unassign(sobek.FunctionCall{})
+	assert.Equal(t, 0, stats(sobek.FunctionCall{}).Export().(map[string]any)["assignments"])
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
+
+func TestConsumerTracksAssignedTopic(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	require.NoError(t, mockCluster.CreateTopic("orders", 1, 1))
+	require.NoError(t, mockCluster.CreateTopic("payments", 1, 1))
+
+	consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:     []string{mockCluster.BootstrapServers()},
+		Assignments: []TopicPartition{{Topic: "orders"}},
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, consumer.Close())
+	}()
+	assert.Equal(t, "orders", consumer.assignedTopic())
+
+	require.NoError(t, consumer.IncrementalAssign([]TopicPartition{{Topic: "payments"}}))
+	assert.Equal(t, "", consumer.assignedTopic())
+
+	require.NoError(t, consumer.Unassign([]TopicPartition{{Topic: "payments"}}))
+	assert.Equal(t, "orders", consumer.assignedTopic())
+
+	require.NoError(t, consumer.Unassign(nil))
+	assert.Equal(t, "", consumer.assignedTopic())
+	require.ErrorIs(t, consumer.Seek(0, 0), errSeekRequiresSingleConfiguredTopic)
+}
@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.k6.io/k6/metrics"
+)
+
+const (
+	commitModeSync  = "sync"
+	commitModeAsync = "async"
+	commitModeAuto  = "auto"
+
+	commitResultSuccess = "success"
+	commitResultError   = "error"
+)
+
+// CommitOffsetsAsync starts committing like CommitOffsets and returns without
+// waiting for the result, which is only reported through the commit metric.
+// Closing the consumer waits for the pending commits.
+func (c *Consumer) CommitOffsetsAsync(partitions ...TopicPartition) error {
+	if c == nil {
+		return newMissingConfigError("consumer")
+	}
+	offsets, err := commitTopicPartitions(partitions, "commit offsets")
+	if err != nil {
+		return err
+	}
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return NewXk6KafkaError(failedCommitConsumer, "Failed to commit consumer offsets.", err)
+		}
+		return err
+	}
+
+	go func() {
+		defer c.endOperation()
+
+		err := commitConsumerOffsets(client, offsets)
+		c.commits.record(commitModeAsync, err)
+		if err != nil {
+			logger.WithField("error", err).Warn("Failed to commit consumer offsets asynchronously.")
+		}
+	}()
+
+	return nil
+}
+
+// commitConsumerOffsets commits offsets, or the offsets consumed so far when
+// there are none.
+func commitConsumerOffsets(client *ckafka.Consumer, offsets []ckafka.TopicPartition) error {
+	var committed []ckafka.TopicPartition
+	var err error
+	if len(offsets) == 0 {
+		committed, err = client.Commit()
+	} else {
+		committed, err = client.CommitOffsets(offsets)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, partition := range committed {
+		if partition.Error != nil && partition.Topic != nil {
+			return fmt.Errorf("%s/%d: %w", *partition.Topic, partition.Partition, partition.Error)
+		}
+	}
+
+	return nil
+}
+
+// commitTopicPartitions validates explicit commit offsets. A committed offset
+// is the next one to read, one after the last processed message.
+func commitTopicPartitions(partitions []TopicPartition, component string) ([]ckafka.TopicPartition, error) {
+	converted := make([]ckafka.TopicPartition, 0, len(partitions))
+	for _, partition := range partitions {
+		if partition.Topic == "" {
+			return nil, newInvalidConfigError(component, errTopicMustNotBeEmpty)
+		}
+		partitionValue, err := consumerPartition(partition.Partition, component)
+		if err != nil {
+			return nil, err
+		}
+		if partition.Offset < 0 {
+			return nil, newInvalidConfigError(component, fmt.Errorf(
+				"%w: %s/%d", errCommitOffsetNegative, partition.Topic, partition.Partition))
+		}
+
+		topic := partition.Topic
+		topicPartition := ckafka.TopicPartition{
+			Topic:     &topic,
+			Partition: partitionValue,
+			Offset:    ckafka.Offset(partition.Offset),
+		}
+		if partition.Metadata != "" {
+			metadata := partition.Metadata
+			topicPartition.Metadata = &metadata
+		}
+		converted = append(converted, topicPartition)
+	}
+
+	return converted, nil
+}
+
+// commitAutoResult records the result of an automatic commit, which
+// librdkafka delivers through Poll. Commits with nothing new to commit are
+// not counted.
+func (c *Consumer) commitAutoResult(event ckafka.OffsetsCommitted) {
+	var kafkaErr ckafka.Error
+	if errors.As(event.Error, &kafkaErr) && kafkaErr.Code() == ckafka.ErrNoOffset {
+		return
+	}
+
+	c.commits.record(commitModeAuto, event.Error)
+}
+
+// reportConsumerCommits pushes the commits counted since the last report.
+// Besides consume, commitOffsets, commitOffsetsAsync and close report them,
+// so commits made right before closing the consumer are not lost.
+func (k *Kafka) reportConsumerCommits(consumer *Consumer) {
+	state := k.vu.State()
+	if state == nil {
+		return
+	}
+	commits := consumer.commits.take()
+	if len(commits) == 0 {
+		return
+	}
+
+	ctm := state.Tags.GetCurrentValues()
+	clientTags := ctm.Tags.With("clientid", compatibilityConfigString(consumer.config, "client.id"))
+	now := time.Now()
+	for commit, count := range commits {
+		metrics.PushIfNotDone(k.vu.Context(), state.Samples, metrics.Sample{
+			Time: now,
+			TimeSeries: metrics.TimeSeries{
+				Metric: k.metrics.ReaderCommits,
+				Tags:   clientTags.With("mode", commit.mode).With("result", commit.result),
+			},
+			Value:    float64(count),
+			Metadata: ctm.Metadata,
+		})
+	}
+}
+
+type commitResult struct {
+	mode   string
+	result string
+}
+
+// commitTracker counts the offset commits of a consumer by mode and result.
+// They are kept until they are reported, since async and automatic commits
+// complete on other goroutines.
+type commitTracker struct {
+	mu     sync.Mutex
+	counts map[commitResult]int
+}
+
+func (t *commitTracker) record(mode string, err error) {
+	if t == nil {
+		return
+	}
+
+	result := commitResult{mode: mode, result: commitResultSuccess}
+	if err != nil {
+		result.result = commitResultError
+	}
+
+	t.mu.Lock()
+	if t.counts == nil {
+		t.counts = make(map[commitResult]int)
+	}
+	t.counts[result]++
+	t.mu.Unlock()
+}
+
+// take returns the commits counted since the last call.
+func (t *commitTracker) take() map[commitResult]int {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := t.counts
+	t.counts = nil
+
+	return counts
+}
@@ -0,0 +1,191 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitTopicPartitions(t *testing.T) {
+	t.Parallel()
+
+	offsets, err := commitTopicPartitions([]TopicPartition{
+		{Topic: "orders", Partition: 1, Offset: 0},
+		{Topic: "orders", Partition: 2, Offset: 42, Metadata: "batch-7"},
+	}, "commit offsets")
+	require.NoError(t, err)
+	require.Len(t, offsets, 2)
+	assert.Equal(t, ckafka.Offset(0), offsets[0].Offset)
+	assert.Nil(t, offsets[0].Metadata)
+	assert.Equal(t, ckafka.Offset(42), offsets[1].Offset)
+	require.NotNil(t, offsets[1].Metadata)
+	assert.Equal(t, "batch-7", *offsets[1].Metadata)
+
+	_, err = commitTopicPartitions([]TopicPartition{{Topic: "orders", Offset: -1}}, "commit offsets")
+	require.ErrorIs(t, err, errCommitOffsetNegative)
+
+	_, err = commitTopicPartitions([]TopicPartition{{Partition: 1}}, "commit offsets")
+	require.ErrorIs(t, err, errTopicMustNotBeEmpty)
+}
+
+func TestCommitTrackerCountsResults(t *testing.T) {
+	t.Parallel()
+
+	tracker := &commitTracker{}
+	tracker.record(commitModeSync, nil)
+	tracker.record(commitModeSync, nil)
+	tracker.record(commitModeAsync, errors.New("boom"))
+
+	assert.Equal(t, map[commitResult]int{
+		{mode: commitModeSync, result: commitResultSuccess}: 2,
+		{mode: commitModeAsync, result: commitResultError}:  1,
+	}, tracker.take())
+	assert.Nil(t, tracker.take())
+
+	var nilTracker *commitTracker
+	nilTracker.record(commitModeAuto, nil)
+	assert.Nil(t, nilTracker.take())
+}
+
+func TestConsumerCommitsOffsets(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-commit-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+		Topic:   topicName,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Value: []byte("0")}, {Value: []byte("1")}, {Value: []byte("2")},
+	}))
+
+	consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:     []string{mockCluster.BootstrapServers()},
+		GroupID:     "consumer-commit-group",
+		GroupTopics: []string{topicName},
+		StartOffset: firstOffset,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, false, consumer.config["enable.auto.commit"])
+
+	messages, err := consumer.Consume(ctx, 3)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+
+	require.NoError(t, consumer.CommitOffsets(ctx, TopicPartition{
+		Topic: topicName, Partition: 0, Offset: 1, Metadata: "first",
+	}))
+	committed, err := consumer.client.Committed([]ckafka.TopicPartition{{Topic: &topicName}}, 5000)
+	require.NoError(t, err)
+	require.Len(t, committed, 1)
+	assert.Equal(t, ckafka.Offset(1), committed[0].Offset)
+	require.NotNil(t, committed[0].Metadata)
+	assert.Equal(t, "first", *committed[0].Metadata)
+
+	// Explicit commits are not reported again as automatic ones.
+	pollCtx, pollCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	_, _ = consumer.Consume(pollCtx, 1)
+	pollCancel()
+
+	require.NoError(t, consumer.CommitOffsetsAsync())
+	// Closing waits for the async commit.
+	require.NoError(t, consumer.Close())
+	assert.Equal(t, map[commitResult]int{
+		{mode: commitModeSync, result: commitResultSuccess}:  1,
+		{mode: commitModeAsync, result: commitResultSuccess}: 1,
+	}, consumer.commits.take())
+
+	// With commitInterval, the consumed offsets are committed automatically.
+	consumer, err = NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:        []string{mockCluster.BootstrapServers()},
+		GroupID:        "consumer-auto-commit-group",
+		GroupTopics:    []string{topicName},
+		StartOffset:    firstOffset,
+		CommitInterval: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, consumer.Close())
+	}()
+
+	messages, err = consumer.Consume(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	require.Eventually(t, func() bool {
+		pollCtx, pollCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer pollCancel()
+		_, _ = consumer.Consume(pollCtx, 1)
+
+		committed, err := consumer.client.Committed([]ckafka.TopicPartition{{Topic: &topicName}}, 5000)
+		return err == nil && len(committed) == 1 && committed[0].Offset >= 1
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.Positive(t, consumer.commits.take()[commitResult{mode: commitModeAuto, result: commitResultSuccess}])
+}
+
+func TestConsumerClassReportsCommitsOnClose(t *testing.T) {
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-commit-close-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+		Topic:   topicName,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte("0")}}))
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":     []string{mockCluster.BootstrapServers()},
+			"groupId":     "consumer-commit-close-group",
+			"groupTopics": []string{topicName},
+			"startOffset": firstOffset,
+			"maxWait":     "10s",
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	messages := consume(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})},
+	}).Export().([]map[string]any)
+	require.Len(t, messages, 1)
+	assert.NotContains(t, test.getMetricValues(), test.module.metrics.ReaderCommits.Name)
+
+	// No consume call follows the commit, so closing reports it.
+	commitOffsetsAsync := consumer.Get("commitOffsetsAsync").Export().(func(sobek.FunctionCall) sobek.Value)
+	commitOffsetsAsync(sobek.FunctionCall{})
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+	assert.Equal(t, 1.0, test.getMetricValues()[test.module.metrics.ReaderCommits.Name])
+}
@@ -0,0 +1,249 @@
+package kafka
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Decode error policies of a deserializer.
+const (
+	decodeErrorThrow = "throw"
+	decodeErrorSkip  = "skip"
+	decodeErrorRaw   = "raw"
+)
+
+// DeserializerConfig decodes the keys or values of consumed messages before
+// they are handed to the script.
+type DeserializerConfig struct {
+	// SchemaType is one of the SCHEMA_TYPE_* constants.
+	SchemaType SchemaType `json:"schemaType"`
+	// SchemaRegistry resolves the schema from the id in the wire format
+	// prefix. It is required for AVRO and PROTOBUF. JSON without it is
+	// decoded as plain JSON.
+	SchemaRegistry *SchemaRegistryConfig `json:"schemaRegistry"`
+	// SubjectNameStrategy set to TOPIC_NAME_STRATEGY only accepts schemas of
+	// the <topic>-key or <topic>-value subject. Otherwise the id is looked up
+	// across all subjects.
+	SubjectNameStrategy string `json:"subjectNameStrategy"`
+	// OnError is one of the DECODE_ERROR_* constants and defaults to
+	// DECODE_ERROR_THROW.
+	OnError string `json:"onError"`
+}
+
+// messageDeserializer decodes the keys or values of consumed messages, on
+// whichever goroutine reads them.
+type messageDeserializer struct {
+	element Element
+	config  DeserializerConfig
+	client  SchemaRegistryClient
+
+	mu sync.Mutex
+	// schemas holds the schemas already resolved, by subject and id, so that
+	// an id checked against one topic's subject is checked again for another.
+	schemas map[schemaCacheKey]*Schema
+	// references holds the referenced schemas resolved by name.
+	references map[string]*Schema
+}
+
+type schemaCacheKey struct {
+	subject string
+	id      int
+}
+
+func newMessageDeserializer(element Element, config *DeserializerConfig) (*messageDeserializer, error) {
+	if config == nil {
+		//nolint: nilnil // without a deserializer the bytes are returned as they are
+		return nil, nil
+	}
+
+	component := string(element) + " deserializer"
+	if config.SchemaType == "" {
+		return nil, newInvalidConfigError(component, errSchemaTypeMustNotBeEmpty)
+	}
+	if _, err := GetSerdes(config.SchemaType); err != nil {
+		return nil, newInvalidConfigError(component, err)
+	}
+
+	switch config.OnError {
+	case "":
+		config.OnError = decodeErrorThrow
+	case decodeErrorThrow, decodeErrorSkip, decodeErrorRaw:
+	default:
+		return nil, newInvalidConfigError(component, errDecodeErrorPolicyInvalid)
+	}
+
+	switch config.SubjectNameStrategy {
+	case "", TopicNameStrategy, RecordNameStrategy, TopicRecordNameStrategy:
+	default:
+		return nil, newInvalidConfigError(component, errSubjectNameStrategyInvalid)
+	}
+
+	deserializer := &messageDeserializer{
+		element:    element,
+		config:     *config,
+		schemas:    make(map[schemaCacheKey]*Schema),
+		references: make(map[string]*Schema),
+	}
+
+	switch {
+	case config.SchemaRegistry != nil:
+		client, err := newSchemaRegistryClient(config.SchemaRegistry)
+		if err != nil {
+			return nil, err
+		}
+		deserializer.client = client
+	case config.SchemaType == Avro || config.SchemaType == Protobuf:
+		return nil, newInvalidConfigError(component, errSchemaRegistryRequired)
+	}
+
+	return deserializer, nil
+}
+
+// deserialize decodes data read from topic into the value handed to the
+// script.
+func (d *messageDeserializer) deserialize(topic string, data []byte) (any, error) {
+	switch d.config.SchemaType {
+	case String:
+		return string(data), nil
+	case Bytes:
+		return data, nil
+	case Json:
+		if d.client == nil {
+			decoded, err := (&JSONSerde{}).Deserialize(data, nil)
+			if err != nil {
+				return nil, err
+			}
+			return decoded, nil
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.config.SchemaType == Protobuf {
+		schemaID, indexes, payload, err := decodeProtobufWireFormat(data)
+		if err != nil {
+			return nil, err
+		}
+		schema, schemaErr := d.schema(topic, schemaID)
+		if schemaErr != nil {
+			return nil, schemaErr
+		}
+		decoded, decodeErr := decodeProtobufPayload(schema, indexes, payload)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		return decoded, nil
+	}
+
+	schemaID, payload, err := parseWireFormat(data)
+	if err != nil {
+		return nil, err
+	}
+	schema, schemaErr := d.schema(topic, schemaID)
+	if schemaErr != nil {
+		return nil, schemaErr
+	}
+	serde, serdeErr := GetSerdes(d.config.SchemaType)
+	if serdeErr != nil {
+		return nil, serdeErr
+	}
+	decoded, decodeErr := serde.Deserialize(payload, schema)
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return decoded, nil
+}
+
+// schema returns the schema with the id, from the registry the first time.
+// d.mu must be held.
+func (d *messageDeserializer) schema(topic string, id int) (*Schema, error) {
+	subject := ""
+	if d.config.SubjectNameStrategy == TopicNameStrategy {
+		subject = topic + "-" + string(d.element)
+	}
+	key := schemaCacheKey{subject: subject, id: id}
+	if schema, ok := d.schemas[key]; ok {
+		return schema, nil
+	}
+
+	registered, err := d.client.GetSchemaByID(subject, id)
+	if err != nil {
+		return nil, NewXk6KafkaError(
+			schemaNotFound, fmt.Sprintf("Failed to get schema %d from schema registry", id), err)
+	}
+
+	schema := &Schema{
+		EnableCaching: true,
+		ID:            id,
+		Schema:        registered.Schema(),
+		SchemaType:    registered.SchemaType(),
+		References:    registered.References(),
+		Subject:       subject,
+		resolver:      newSchemaResolver(d.client, d.references, true),
+	}
+	d.schemas[key] = schema
+
+	return schema, nil
+}
+
+func (d *messageDeserializer) close() {
+	if d != nil && d.client != nil {
+		_ = d.client.Close()
+	}
+}
+
+// decode deserializes the key and value of message with the deserializers of
+// the consumer. It reports false for a message to skip.
+func (c *Consumer) decode(message *Message) (bool, error) {
+	for _, deserializer := range []*messageDeserializer{c.keyDeserializer, c.valueDeserializer} {
+		if deserializer == nil {
+			continue
+		}
+
+		data := message.Value
+		if deserializer.element == Key {
+			data = message.Key
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		decoded, err := deserializer.deserialize(message.Topic, data)
+		if err != nil {
+			entry := logDecodeError(message, deserializer.element, err)
+			switch deserializer.config.OnError {
+			case decodeErrorSkip:
+				entry.Warn("Skipping message that failed to deserialize.")
+				return false, nil
+			case decodeErrorRaw:
+				entry.Warn("Returning message that failed to deserialize as bytes.")
+				continue
+			default:
+				return false, NewXk6KafkaError(failedDecodeMessage, fmt.Sprintf(
+					"Failed to deserialize the %s of %s/%d at offset %d.",
+					deserializer.element, message.Topic, message.Partition, message.Offset), err)
+			}
+		}
+
+		if deserializer.element == Key {
+			message.decodedKey = decoded
+		} else {
+			message.decodedValue = decoded
+		}
+	}
+
+	return true, nil
+}
+
+func logDecodeError(message *Message, element Element, err error) *logrus.Entry {
+	return logger.WithFields(logrus.Fields{
+		"topic":     message.Topic,
+		"partition": message.Partition,
+		"offset":    message.Offset,
+		"element":   element,
+		"error":     err,
+	})
+}
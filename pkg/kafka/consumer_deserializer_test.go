@@ -0,0 +1,172 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	cschemaregistry "github.com/confluentinc/confluent-kafka-go/v2/schemaregistry"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageDeserializerValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	deserializer, err := newMessageDeserializer(Value, nil)
+	require.NoError(t, err)
+	assert.Nil(t, deserializer)
+
+	deserializer, err = newMessageDeserializer(Key, &DeserializerConfig{SchemaType: String})
+	require.NoError(t, err)
+	assert.Equal(t, decodeErrorThrow, deserializer.config.OnError)
+
+	tests := []struct {
+		name   string
+		config DeserializerConfig
+		err    error
+	}{
+		{"missing schema type", DeserializerConfig{}, errSchemaTypeMustNotBeEmpty},
+		{"unknown schema type", DeserializerConfig{SchemaType: "XML"}, ErrUnknownSerdesType},
+		{"unknown policy", DeserializerConfig{SchemaType: Json, OnError: "ignore"}, errDecodeErrorPolicyInvalid},
+		{
+			"unknown strategy",
+			DeserializerConfig{SchemaType: Json, SubjectNameStrategy: "SubjectStrategy"},
+			errSubjectNameStrategyInvalid,
+		},
+		{"avro without registry", DeserializerConfig{SchemaType: Avro}, errSchemaRegistryRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newMessageDeserializer(Value, &tt.config)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestConsumerClassDeserializesMessages(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	topicName := "consumer-deserializer-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	// Every mock registry client has its own schemas, so the consumers are
+	// given the one the schema is registered with.
+	srClient, err := cschemaregistry.NewClient(cschemaregistry.NewConfig("mock://consumer-deserializer"))
+	require.NoError(t, err)
+	registry := newConfluentSchemaRegistryAdapter(srClient, true)
+	registered, err := registry.CreateSchema(topicName+"-value", avroSchemaForSRTests, Avro)
+	require.NoError(t, err)
+	data, serdeErr := (&AvroSerde{}).Serialize(map[string]any{"field": "value"}, &Schema{Schema: avroSchemaForSRTests})
+	require.Nil(t, serdeErr)
+	encoded := test.module.encodeWireFormat(data, registered.ID())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Key: []byte("key-0"), Value: encoded},
+		{Key: []byte("key-1"), Value: []byte("not avro")},
+		{Value: encoded},
+	}))
+
+	newConsumer := func(onError string) (*sobek.Object, func(int) []map[string]any) {
+		consumer := test.module.consumerClass(sobek.ConstructorCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+				"brokers":         []string{mockCluster.BootstrapServers()},
+				"topic":           topicName,
+				"keyDeserializer": map[string]any{"schemaType": String},
+				"valueDeserializer": map[string]any{
+					"schemaType":          Avro,
+					"schemaRegistry":      map[string]any{"url": "mock://consumer-deserializer"},
+					"subjectNameStrategy": TopicNameStrategy,
+					"onError":             onError,
+				},
+			})},
+		})
+		require.NotNil(t, consumer)
+		consumer.Get("This").Export().(*Consumer).valueDeserializer.client = registry
+
+		consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+		return consumer, func(maxMessages int) []map[string]any {
+			return consume(sobek.FunctionCall{
+				Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": maxMessages})},
+			}).Export().([]map[string]any)
+		}
+	}
+	closeConsumer := func(consumer *sobek.Object) {
+		closeFn := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		assert.Nil(t, closeFn(sobek.FunctionCall{}).Export())
+	}
+
+	// DECODE_ERROR_SKIP leaves out the message that is not Avro.
+	consumer, consume := newConsumer(decodeErrorSkip)
+	messages := consume(2)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "key-0", messages[0]["key"])
+	assert.Equal(t, map[string]any{"field": "value"}, messages[0]["value"])
+	assert.Equal(t, int64(2), messages[1]["offset"])
+	assert.NotContains(t, messages[1], "key")
+	assert.Equal(t, map[string]any{"field": "value"}, messages[1]["value"])
+	closeConsumer(consumer)
+
+	// DECODE_ERROR_RAW returns it as bytes.
+	consumer, consume = newConsumer(decodeErrorRaw)
+	messages = consume(3)
+	require.Len(t, messages, 3)
+	assert.Equal(t, "key-1", messages[1]["key"])
+	assert.Equal(t, []byte("not avro"), messages[1]["value"])
+	closeConsumer(consumer)
+
+	// DECODE_ERROR_THROW, the default, fails the consume call.
+	consumer, consume = newConsumer("")
+	require.Len(t, consume(1), 1)
+	requireGoErrorMessage(t, func() {
+		consume(1)
+	}, "Failed to deserialize the value of consumer-deserializer-topic/0 at offset 1., "+
+		"OriginalError: Invalid message: invalid start byte.")
+	closeConsumer(consumer)
+}
+
+func TestMessageDeserializerChecksSubjectOfCachedSchemas(t *testing.T) {
+	t.Parallel()
+
+	srClient, err := cschemaregistry.NewClient(cschemaregistry.NewConfig("mock://deserializer-subjects"))
+	require.NoError(t, err)
+	registry := newConfluentSchemaRegistryAdapter(srClient, true)
+	registered, err := registry.CreateSchema("orders-value", avroSchemaForSRTests, Avro)
+	require.NoError(t, err)
+
+	deserializer, err := newMessageDeserializer(Value, &DeserializerConfig{
+		SchemaType:          Avro,
+		SchemaRegistry:      &SchemaRegistryConfig{URL: "mock://deserializer-subjects"},
+		SubjectNameStrategy: TopicNameStrategy,
+	})
+	require.NoError(t, err)
+	deserializer.client = registry
+
+	schema, err := deserializer.schema("orders", registered.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "orders-value", schema.Subject)
+
+	_, err = deserializer.schema("payments", registered.ID())
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package kafka
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.k6.io/k6/metrics"
+)
+
+// MessageFilter drops consumed messages in Go, before they are converted
+// for the script. A message is returned only if it matches every condition
+// that is set.
+type MessageFilter struct {
+	// HeaderEquals maps header names to the value they must have.
+	HeaderEquals map[string]string `json:"headerEquals"`
+	// HeaderExists lists headers the message must have, with any value.
+	HeaderExists []string `json:"headerExists"`
+	KeyPrefix    string   `json:"keyPrefix"`
+	// KeyRegex is matched against the key, anywhere in it unless anchored.
+	KeyRegex   string `json:"keyRegex"`
+	Partitions []int  `json:"partitions"`
+	// TimestampFrom and TimestampTo bound the message time. TimestampFrom is
+	// inclusive and TimestampTo exclusive.
+	TimestampFrom time.Time `json:"timestampFrom"`
+	TimestampTo   time.Time `json:"timestampTo"`
+}
+
+type messageFilter struct {
+	config     MessageFilter
+	keyRegex   *regexp.Regexp
+	partitions map[int]struct{}
+}
+
+func newMessageFilter(config *MessageFilter) (*messageFilter, *Xk6KafkaError) {
+	if config == nil {
+		//nolint: nilnil // without a filter every message is returned
+		return nil, nil
+	}
+
+	filter := &messageFilter{config: *config}
+	if config.KeyRegex != "" {
+		keyRegex, err := regexp.Compile(config.KeyRegex)
+		if err != nil {
+			return nil, newInvalidConfigError("filter", err)
+		}
+		filter.keyRegex = keyRegex
+	}
+	if config.Partitions != nil {
+		filter.partitions = make(map[int]struct{}, len(config.Partitions))
+		for _, partition := range config.Partitions {
+			filter.partitions[partition] = struct{}{}
+		}
+	}
+	if !config.TimestampFrom.IsZero() && !config.TimestampTo.IsZero() &&
+		!config.TimestampFrom.Before(config.TimestampTo) {
+		return nil, newInvalidConfigError("filter", errFilterTimestampRange)
+	}
+
+	return filter, nil
+}
+
+// match reports whether message passes the filter.
+func (f *messageFilter) match(message *Message) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.partitions != nil {
+		if _, ok := f.partitions[message.Partition]; !ok {
+			return false
+		}
+	}
+	if !f.config.TimestampFrom.IsZero() && message.Time.Before(f.config.TimestampFrom) {
+		return false
+	}
+	if !f.config.TimestampTo.IsZero() && !message.Time.Before(f.config.TimestampTo) {
+		return false
+	}
+	if !bytes.HasPrefix(message.Key, []byte(f.config.KeyPrefix)) {
+		return false
+	}
+	if f.keyRegex != nil && !f.keyRegex.Match(message.Key) {
+		return false
+	}
+	for _, name := range f.config.HeaderExists {
+		if _, ok := message.Headers[name]; !ok {
+			return false
+		}
+	}
+	for name, want := range f.config.HeaderEquals {
+		value, ok := message.Headers[name].([]byte)
+		if !ok || string(value) != want {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filter returns the messages that pass the filter, and counts the others in
+// filtered.
+func (f *messageFilter) filter(messages []Message, filtered map[consumerMetricKey]int) []Message {
+	if f == nil {
+		return messages
+	}
+
+	kept := messages[:0:0]
+	for _, message := range messages {
+		if f.match(&message) {
+			kept = append(kept, message)
+			continue
+		}
+		filtered[consumerMetricKey{topic: message.Topic, partition: message.Partition}]++
+	}
+	return kept
+}
+
+// reportFilteredMessages pushes the number of messages the filter of a
+// consume call dropped.
+func (k *Kafka) reportFilteredMessages(consumer *Consumer, filtered map[consumerMetricKey]int) {
+	state := k.vu.State()
+	if state == nil || len(filtered) == 0 {
+		return
+	}
+
+	ctm := state.Tags.GetCurrentValues()
+	clientID := compatibilityConfigString(consumer.config, "client.id")
+	now := time.Now()
+	for key, count := range filtered {
+		sampleTags := ctm.Tags.With("topic", key.topic)
+		sampleTags = sampleTags.With("clientid", clientID)
+		sampleTags = sampleTags.With("partition", strconv.Itoa(key.partition))
+		metrics.PushIfNotDone(k.vu.Context(), state.Samples, metrics.Sample{
+			Time: now,
+			TimeSeries: metrics.TimeSeries{
+				Metric: k.metrics.ReaderFiltered,
+				Tags:   sampleTags,
+			},
+			Value:    float64(count),
+			Metadata: ctm.Metadata,
+		})
+	}
+}
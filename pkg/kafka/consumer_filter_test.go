@@ -0,0 +1,195 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	message := Message{
+		Partition: 1,
+		Key:       []byte("order-42"),
+		Headers:   map[string]any{"type": []byte("created"), "trace": []byte{}},
+		Time:      now,
+	}
+
+	tests := []struct {
+		name   string
+		filter *MessageFilter
+		match  bool
+	}{
+		{"no filter", nil, true},
+		{"empty filter", &MessageFilter{}, true},
+		{"header equals", &MessageFilter{HeaderEquals: map[string]string{"type": "created"}}, true},
+		{"header differs", &MessageFilter{HeaderEquals: map[string]string{"type": "deleted"}}, false},
+		{"header exists", &MessageFilter{HeaderExists: []string{"trace"}}, true},
+		{"header missing", &MessageFilter{HeaderExists: []string{"span"}}, false},
+		{"key prefix", &MessageFilter{KeyPrefix: "order-"}, true},
+		{"other key prefix", &MessageFilter{KeyPrefix: "user-"}, false},
+		{"key regex", &MessageFilter{KeyRegex: `-\d+$`}, true},
+		{"other key regex", &MessageFilter{KeyRegex: `^user`}, false},
+		{"partition", &MessageFilter{Partitions: []int{0, 1}}, true},
+		{"other partition", &MessageFilter{Partitions: []int{0}}, false},
+		{"no partitions", &MessageFilter{Partitions: []int{}}, false},
+		{"timestamp from", &MessageFilter{TimestampFrom: now}, true},
+		{"timestamp to", &MessageFilter{TimestampTo: now}, false},
+		{
+			"timestamp range",
+			&MessageFilter{TimestampFrom: now.Add(-time.Minute), TimestampTo: now.Add(time.Minute)},
+			true,
+		},
+		{"all conditions", &MessageFilter{KeyPrefix: "order-", Partitions: []int{2}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := newMessageFilter(tt.filter)
+			require.Nil(t, err)
+			assert.Equal(t, tt.match, filter.match(&message))
+		})
+	}
+}
+
+func TestNewMessageFilterValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := newMessageFilter(&MessageFilter{KeyRegex: "("})
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Invalid filter")
+
+	now := time.Now()
+	_, err = newMessageFilter(&MessageFilter{TimestampFrom: now, TimestampTo: now})
+	require.NotNil(t, err)
+	require.ErrorIs(t, err, errFilterTimestampRange)
+}
+
+func TestConsumerClassFiltersMessages(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	topicName := "consumer-filter-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Key: []byte("order-0"), Value: []byte("0"), Headers: map[string]any{"type": "created"}},
+		{Key: []byte("user-1"), Value: []byte("1"), Headers: map[string]any{"type": "created"}},
+		{Key: []byte("order-2"), Value: []byte("2"), Headers: map[string]any{"type": "deleted"}},
+		{Key: []byte("order-3"), Value: []byte("3"), Headers: map[string]any{"type": "created"}},
+	}))
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+			"topic":   topicName,
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		consume(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"filter": map[string]any{"keyRegex": "("},
+		})}})
+	}, "Invalid filter, OriginalError: error parsing regexp: missing closing ): `(`")
+
+	messages := consume(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+		"maxMessages": 2,
+		"filter": map[string]any{
+			"keyPrefix":    "order-",
+			"headerEquals": map[string]any{"type": "created"},
+		},
+	})}}).Export().([]map[string]any)
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(0), messages[0]["offset"])
+	assert.Equal(t, int64(3), messages[1]["offset"])
+
+	metricsValues := test.getCounterMetricsValues()
+	assert.Equal(t, 2.0, metricsValues[test.module.metrics.ReaderFiltered.Name])
+	assert.Equal(t, 4.0, metricsValues[test.module.metrics.ReaderMessages.Name])
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
+
+func TestConsumerClassFilterReadsForAtMostMaxWait(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	topicName := "consumer-filter-max-wait-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	// A backlog the filter drops keeps the consumer busy for longer than
+	// maxWait.
+	const backlog = 50000
+	messages := make([]Message, backlog)
+	for i := range messages {
+		messages[i] = Message{Key: []byte("other"), Value: []byte("value")}
+	}
+	require.NoError(t, producer.Produce(ctx, messages))
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":     []string{mockCluster.BootstrapServers()},
+			"topic":       topicName,
+			"startOffset": firstOffset,
+			"maxWait":     "100ms",
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	consumed := consume(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+		"maxMessages":   1,
+		"expectTimeout": true,
+		"filter":        map[string]any{"keyPrefix": "order-"},
+	})}}).Export().([]map[string]any)
+	assert.Empty(t, consumed)
+	// The call times out before it has read the whole backlog.
+	assert.Less(t, test.getCounterMetricsValues()[test.module.metrics.ReaderFiltered.Name], float64(backlog))
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
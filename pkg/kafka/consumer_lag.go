@@ -0,0 +1,112 @@
+package kafka
+
+import (
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// setHighWaterMark fills in the high watermark of the partition msg was read
+// from. librdkafka caches it from every fetch response, so no request is sent.
+// The cached value can lag behind the message itself, but the log always ends
+// after the last consumed message.
+func setHighWaterMark(client *ckafka.Consumer, msg *Message) {
+	msg.HighWaterMark = msg.Offset + 1
+
+	partition, err := consumerPartition(msg.Partition, "consumer message")
+	if err != nil {
+		return
+	}
+
+	_, high, err := client.GetWatermarkOffsets(msg.Topic, partition)
+	if err == nil && high > msg.HighWaterMark {
+		msg.HighWaterMark = high
+	}
+}
+
+// consumerLag returns how many messages are left to read in a partition whose
+// log ends at highWaterMark when the next offset to read is position.
+func consumerLag(highWaterMark, position int64) int64 {
+	if position < 0 || highWaterMark <= position {
+		return 0
+	}
+
+	return highWaterMark - position
+}
+
+// startLagSampler samples the lag of every assigned partition each interval
+// until the consumer is closed. The samples are reported with the next
+// consume call.
+func (c *Consumer) startLagSampler(interval time.Duration) {
+	c.lagDone = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.lagDone:
+				return
+			case <-ticker.C:
+				c.sampleLag()
+			}
+		}
+	}()
+}
+
+// sampleLag uses the watermarks librdkafka caches from its background fetches.
+// Querying the broker instead would wait behind any fetch the broker holds
+// for up to fetch.wait.max.ms.
+func (c *Consumer) sampleLag() {
+	client, err := c.beginOperation()
+	if err != nil {
+		return
+	}
+	defer c.endOperation()
+
+	assignments, err := client.Assignment()
+	if err != nil || len(assignments) == 0 {
+		return
+	}
+	positions, err := client.Position(assignments)
+	if err != nil {
+		logger.WithField("error", err).Debug("Failed to query consumer positions for lag.")
+		return
+	}
+
+	lags := make(map[consumerMetricKey]int64, len(positions))
+	for _, position := range positions {
+		// Partitions without a position have not been fetched from yet.
+		if position.Topic == nil || position.Offset < 0 {
+			continue
+		}
+
+		_, high, err := client.GetWatermarkOffsets(*position.Topic, position.Partition)
+		if err != nil || high < 0 {
+			continue
+		}
+
+		key := consumerMetricKey{topic: *position.Topic, partition: int(position.Partition)}
+		lags[key] = consumerLag(high, int64(position.Offset))
+	}
+
+	c.lagMu.Lock()
+	c.lags = lags
+	c.lagMu.Unlock()
+}
+
+// takeSampledLags returns the lags sampled since the last call.
+func (c *Consumer) takeSampledLags() map[consumerMetricKey]int64 {
+	if c == nil {
+		return nil
+	}
+
+	c.lagMu.Lock()
+	defer c.lagMu.Unlock()
+
+	lags := c.lags
+	c.lags = nil
+
+	return lags
+}
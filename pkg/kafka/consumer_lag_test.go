@@ -0,0 +1,80 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerLag(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, int64(3), consumerLag(5, 2))
+	assert.Equal(t, int64(0), consumerLag(5, 5))
+	assert.Equal(t, int64(0), consumerLag(5, int64(ckafka.OffsetInvalid)))
+}
+
+func TestConsumerClassReportsHighWaterMarkAndLag(t *testing.T) {
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-lag-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Value: []byte("0")}, {Value: []byte("1")}, {Value: []byte("2")}, {Value: []byte("3")}, {Value: []byte("4")},
+	}))
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":         []string{mockCluster.BootstrapServers()},
+			"topic":           topicName,
+			"startOffset":     firstOffset,
+			"maxWait":         "5s",
+			"readLagInterval": int64(20 * time.Millisecond),
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	messages := consume(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 2})},
+	}).Export().([]map[string]any)
+	require.Len(t, messages, 2)
+	assert.Equal(t, int64(5), messages[1]["highWaterMark"])
+
+	metricsValues := test.getMetricValues()
+	assert.Equal(t, 3.0, metricsValues[test.module.metrics.ReaderLag.Name])
+
+	kafkaConsumer, ok := consumer.Get("This").Export().(*Consumer)
+	require.True(t, ok)
+	require.Eventually(t, func() bool {
+		kafkaConsumer.lagMu.Lock()
+		defer kafkaConsumer.lagMu.Unlock()
+		return kafkaConsumer.lags[consumerMetricKey{topic: topicName}] == 3
+	}, 5*time.Second, 20*time.Millisecond)
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
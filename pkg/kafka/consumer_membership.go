@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// groupInstanceIDVUPlaceholder in groupInstanceId is replaced with the VU id,
+// so that every VU keeps its own static identity across iterations.
+const groupInstanceIDVUPlaceholder = "{vu}"
+
+// librdkafkaDefaultClientID is the client.id of consumers that do not set one.
+const librdkafkaDefaultClientID = "rdkafka"
+
+// vuID returns the id of the VU. The init context has no state yet, but sets
+// the __VU global.
+func (k *Kafka) vuID() uint64 {
+	if state := k.vu.State(); state != nil {
+		return state.VUID
+	}
+	if vu := k.vu.Runtime().Get("__VU"); vu != nil {
+		return uint64(max(vu.ToInteger(), 0))
+	}
+	return 0
+}
+
+func expandGroupInstanceID(groupInstanceID string, vuID uint64) string {
+	return strings.ReplaceAll(groupInstanceID, groupInstanceIDVUPlaceholder, strconv.FormatUint(vuID, 10))
+}
+
+// memberID returns the member id the group coordinator assigned to the
+// consumer. The Go client does not expose it, so it is looked up among the
+// members of the group: the member with the consumer's group instance id or,
+// without one, the only member with its client id and assignment. It is ""
+// before the consumer joins, and when the lookup fails or is ambiguous.
+func (c *Consumer) memberID(ctx context.Context, client *ckafka.Consumer, assignment []ckafka.TopicPartition) string {
+	groupID, _ := c.config["group.id"].(string)
+	if !c.group || groupID == "" {
+		return ""
+	}
+
+	ctx = ensureContext(ctx)
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultConfluentTimeout)
+		defer cancel()
+	}
+
+	admin, err := ckafka.NewAdminClientFromConsumer(client)
+	if err != nil {
+		return ""
+	}
+	defer admin.Close()
+
+	descriptions, err := (&AdminClient{client: admin}).DescribeConsumerGroups(ctx, []string{groupID})
+	if err != nil || len(descriptions) != 1 || descriptions[0].Error != nil {
+		return ""
+	}
+
+	groupInstanceID, _ := c.config["group.instance.id"].(string)
+	clientID, _ := c.config["client.id"].(string)
+	if clientID == "" {
+		clientID = librdkafkaDefaultClientID
+	}
+	return findMemberID(descriptions[0].Members, groupInstanceID, clientID, topicPartitionsFromConfluent(assignment))
+}
+
+// findMemberID returns the id of the member with groupInstanceID or, without
+// one, of the only member with clientID and assignment.
+func findMemberID(
+	members []ConsumerGroupMember,
+	groupInstanceID string,
+	clientID string,
+	assignment []TopicPartition,
+) string {
+	var memberID string
+	for _, member := range members {
+		if groupInstanceID != "" {
+			if member.GroupInstanceID == groupInstanceID {
+				return member.MemberID
+			}
+			continue
+		}
+		if member.ClientID != clientID || !sameTopicPartitions(member.Assignment, assignment) {
+			continue
+		}
+		if memberID != "" {
+			return ""
+		}
+		memberID = member.MemberID
+	}
+	return memberID
+}
+
+// sameTopicPartitions reports whether a and b hold the same partitions,
+// ignoring offsets and order.
+func sameTopicPartitions(a, b []TopicPartition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	partitions := make(map[consumerMetricKey]struct{}, len(a))
+	for _, partition := range a {
+		partitions[consumerMetricKey{topic: partition.Topic, partition: partition.Partition}] = struct{}{}
+	}
+	for _, partition := range b {
+		if _, ok := partitions[consumerMetricKey{topic: partition.Topic, partition: partition.Partition}]; !ok {
+			return false
+		}
+	}
+	return true
+}
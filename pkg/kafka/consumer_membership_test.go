@@ -0,0 +1,107 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandGroupInstanceID(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "member-3", expandGroupInstanceID("member-{vu}", 3))
+	assert.Equal(t, "3-{v}-3", expandGroupInstanceID("{vu}-{v}-{vu}", 3))
+	assert.Equal(t, "member", expandGroupInstanceID("member", 3))
+}
+
+func TestConsumerClassStaticMembership(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+	test.vu.StateField.VUID = 7
+
+	topicName := "consumer-membership-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte("value")}}))
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":         []string{mockCluster.BootstrapServers()},
+			"groupId":         "consumer-membership-group",
+			"groupTopics":     []string{topicName},
+			"groupInstanceId": "member-{vu}",
+			"maxWait":         "10s",
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	stats := consumer.Get("stats").Export().(func(sobek.FunctionCall) sobek.Value)
+	consumerStats := stats(sobek.FunctionCall{}).Export().(map[string]any)
+	assert.Equal(t, "member-7", consumerStats["groupInstanceId"])
+	assert.Equal(t, "", consumerStats["memberId"])
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	messages := consume(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})},
+	}).Export().([]map[string]any)
+	require.Len(t, messages, 1)
+
+	// The mock cluster cannot describe groups, so the member id stays unknown.
+	consumerStats = stats(sobek.FunctionCall{}).Export().(map[string]any)
+	assert.Equal(t, "", consumerStats["memberId"])
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
+
+func TestFindMemberID(t *testing.T) {
+	t.Parallel()
+
+	orders0 := []TopicPartition{{Topic: "orders", Partition: 0}}
+	orders1 := []TopicPartition{{Topic: "orders", Partition: 1}}
+	members := []ConsumerGroupMember{
+		{MemberID: "static-1", GroupInstanceID: "member-1", ClientID: "rdkafka", Assignment: orders1},
+		{MemberID: "dynamic-1", ClientID: "rdkafka", Assignment: orders0},
+		{MemberID: "dynamic-2", ClientID: "rdkafka"},
+		{MemberID: "dynamic-3", ClientID: "rdkafka"},
+		{MemberID: "other-1", ClientID: "other", Assignment: orders0},
+	}
+
+	assert.Equal(t, "static-1", findMemberID(members, "member-1", "rdkafka", nil))
+	assert.Equal(t, "", findMemberID(members, "member-2", "rdkafka", orders1))
+	assert.Equal(t, "dynamic-1", findMemberID(members, "", "rdkafka", orders0))
+	assert.Equal(t, "other-1", findMemberID(members, "", "other", orders0))
+	// Members without partitions cannot be told apart.
+	assert.Equal(t, "", findMemberID(members, "", "rdkafka", nil))
+}
+
+func TestSameTopicPartitions(t *testing.T) {
+	t.Parallel()
+
+	a := []TopicPartition{{Topic: "orders", Partition: 0}, {Topic: "orders", Partition: 1, Offset: 5}}
+	assert.True(t, sameTopicPartitions(a, []TopicPartition{{Topic: "orders", Partition: 1}, {Topic: "orders"}}))
+	assert.False(t, sameTopicPartitions(a, []TopicPartition{{Topic: "orders", Partition: 1}}))
+	assert.False(t, sameTopicPartitions(a, []TopicPartition{{Topic: "orders", Partition: 1}, {Topic: "payments"}}))
+	assert.True(t, sameTopicPartitions(nil, nil))
+}
@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Pause stops fetching from assigned partitions until they are resumed, even
+// if they are unassigned and assigned again in between: assignments and
+// rebalances pause them again rather than rely on librdkafka keeping the pause
+// state of unassigned partitions. The consumer must still be polled: a group
+// consumer that does not call consume within max.poll.interval.ms leaves the
+// group, paused or not.
+func (c *Consumer) Pause(partitions []TopicPartition) error {
+	return c.changePause("Failed to pause partitions.", "pause", partitions, true)
+}
+
+// Resume fetches again from paused partitions.
+func (c *Consumer) Resume(partitions []TopicPartition) error {
+	return c.changePause("Failed to resume partitions.", "resume", partitions, false)
+}
+
+func (c *Consumer) changePause(message, component string, partitions []TopicPartition, pause bool) error {
+	if c == nil {
+		return newMissingConfigError("consumer")
+	}
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return NewXk6KafkaError(failedPausePartitions, message, err)
+		}
+		return err
+	}
+	defer c.endOperation()
+
+	confluentPartitions, err := confluentTopicPartitions(partitions, c.startOffset, component)
+	if err != nil {
+		return err
+	}
+
+	assignment, err := client.Assignment()
+	if err != nil {
+		return NewXk6KafkaError(failedPausePartitions, "Failed to read consumer assignment.", err)
+	}
+	assigned := make(map[consumerMetricKey]struct{}, len(assignment))
+	for _, partition := range assignment {
+		assigned[confluentPartitionKey(partition)] = struct{}{}
+	}
+	for _, partition := range partitions {
+		key := consumerMetricKey{topic: partition.Topic, partition: partition.Partition}
+		if _, ok := assigned[key]; !ok {
+			return newInvalidConfigError(component, fmt.Errorf(
+				"%w: %s/%d", errPartitionNotAssigned, partition.Topic, partition.Partition))
+		}
+	}
+
+	if pause {
+		err = client.Pause(confluentPartitions)
+	} else {
+		err = client.Resume(confluentPartitions)
+	}
+	if err != nil {
+		return NewXk6KafkaError(failedPausePartitions, message, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, partition := range partitions {
+		key := consumerMetricKey{topic: partition.Topic, partition: partition.Partition}
+		if pause {
+			c.paused[key] = struct{}{}
+		} else {
+			delete(c.paused, key)
+		}
+	}
+
+	return nil
+}
+
+// pauseAgain pauses the partitions among assigned that were paused before
+// they were last unassigned.
+func (c *Consumer) pauseAgain(client *ckafka.Consumer, assigned []ckafka.TopicPartition) error {
+	c.mu.Lock()
+	var paused []ckafka.TopicPartition
+	for _, partition := range assigned {
+		if _, ok := c.paused[confluentPartitionKey(partition)]; ok {
+			paused = append(paused, ckafka.TopicPartition{Topic: partition.Topic, Partition: partition.Partition})
+		}
+	}
+	c.mu.Unlock()
+
+	if len(paused) == 0 {
+		return nil
+	}
+	return client.Pause(paused)
+}
+
+// pausedPartitions returns the paused partitions among assignment, sorted.
+// The others are paused again when they are assigned.
+func (c *Consumer) pausedPartitions(assignment []ckafka.TopicPartition) []TopicPartition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var paused []TopicPartition
+	for _, partition := range assignment {
+		key := confluentPartitionKey(partition)
+		if _, ok := c.paused[key]; ok {
+			paused = append(paused, TopicPartition{Topic: key.topic, Partition: key.partition})
+		}
+	}
+	sort.Slice(paused, func(i, j int) bool {
+		if paused[i].Topic != paused[j].Topic {
+			return paused[i].Topic < paused[j].Topic
+		}
+		return paused[i].Partition < paused[j].Partition
+	})
+
+	return paused
+}
+
+func confluentPartitionKey(partition ckafka.TopicPartition) consumerMetricKey {
+	key := consumerMetricKey{partition: int(partition.Partition)}
+	if partition.Topic != nil {
+		key.topic = *partition.Topic
+	}
+
+	return key
+}
@@ -0,0 +1,158 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumerClassPausesPartitions(t *testing.T) {
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-pause-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 2, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	produce := func(partition int) {
+		require.NoError(t, producer.Produce(ctx, []Message{
+			{Partition: partition, partitionSet: true, Value: []byte("value")},
+		}))
+	}
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+			"assignments": []map[string]any{
+				{"topic": topicName, "partition": 0},
+				{"topic": topicName, "partition": 1},
+			},
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	partition0 := test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 0}})
+	pause := consumer.Get("pause").Export().(func(sobek.FunctionCall) sobek.Value)
+	pause(sobek.FunctionCall{Arguments: []sobek.Value{partition0}})
+
+	stats := consumer.Get("stats").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Equal(t, []map[string]any{{"topic": topicName, "partition": 0}},
+		stats(sobek.FunctionCall{}).Export().(map[string]any)["paused"])
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	consumePartition := func() int {
+		messages := consume(sobek.FunctionCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})},
+		}).Export().([]map[string]any)
+		require.Len(t, messages, 1)
+		return messages[0]["partition"].(int)
+	}
+
+	// The message of the paused partition is only read once it resumes.
+	produce(0)
+	produce(1)
+	assert.Equal(t, 1, consumePartition())
+
+	resume := consumer.Get("resume").Export().(func(sobek.FunctionCall) sobek.Value)
+	resume(sobek.FunctionCall{Arguments: []sobek.Value{partition0}})
+	assert.Empty(t, stats(sobek.FunctionCall{}).Export().(map[string]any)["paused"])
+	assert.Equal(t, 0, consumePartition())
+
+	// Partitions stay paused when they are assigned again.
+	pause(sobek.FunctionCall{Arguments: []sobek.Value{partition0}})
+	unassign := consumer.Get("unassign").Export().(func(sobek.FunctionCall) sobek.Value)
+	unassign(sobek.FunctionCall{Arguments: []sobek.Value{partition0}})
+	assert.Empty(t, stats(sobek.FunctionCall{}).Export().(map[string]any)["paused"])
+	incrementalAssign := consumer.Get("incrementalAssign").Export().(func(sobek.FunctionCall) sobek.Value)
+	incrementalAssign(sobek.FunctionCall{Arguments: []sobek.Value{
+		test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 0, "offset": 1}}),
+	}})
+	assert.Equal(t, []map[string]any{{"topic": topicName, "partition": 0}},
+		stats(sobek.FunctionCall{}).Export().(map[string]any)["paused"])
+	produce(0)
+	produce(1)
+	assert.Equal(t, 1, consumePartition())
+	resume(sobek.FunctionCall{Arguments: []sobek.Value{partition0}})
+	assert.Equal(t, 0, consumePartition())
+
+	requireGoErrorMessage(t, func() {
+		pause(sobek.FunctionCall{Arguments: []sobek.Value{
+			test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 2}}),
+		}})
+	}, "Invalid pause, OriginalError: partition is not assigned to the consumer: consumer-pause-topic/2")
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
+
+func TestConsumerPausesPartitionsAgainAfterRebalance(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-pause-rebalance-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 2, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	partitions := []TopicPartition{{Topic: topicName, Partition: 0}, {Topic: topicName, Partition: 1}}
+	consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:     []string{mockCluster.BootstrapServers()},
+		Assignments: partitions,
+		StartOffset: firstOffset,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, consumer.Close())
+	}()
+	require.NoError(t, consumer.Pause(partitions[:1]))
+
+	// Revoke and assign the partitions again the way the rebalance callback
+	// does.
+	require.NoError(t, consumer.client.Unassign())
+	confluentPartitions, err := confluentTopicPartitions(partitions, firstOffset, "assign")
+	require.NoError(t, err)
+	require.NoError(t, consumer.rebalance(consumer.client, ckafka.AssignedPartitions{Partitions: confluentPartitions}))
+	require.NoError(t, consumer.rebalances.takeError())
+	assert.Equal(t, partitions[:1], consumer.Stats().Paused)
+
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Partition: 0, partitionSet: true, Value: []byte("value")},
+		{Partition: 1, partitionSet: true, Value: []byte("value")},
+	}))
+	messages, err := consumer.Consume(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, 1, messages[0].Partition)
+}
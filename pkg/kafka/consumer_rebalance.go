@@ -0,0 +1,260 @@
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const (
+	rebalanceAssigned = "assigned"
+	rebalanceRevoked  = "revoked"
+
+	rebalanceProtocolCooperative = "cooperative"
+)
+
+// TopicPartition identifies a partition of a topic, together with an offset
+// in it.
+type TopicPartition struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	// Metadata is stored with committed offsets.
+	Metadata string `json:"metadata,omitempty"`
+}
+
+// RebalanceHandler is called when partitions are assigned to or revoked from
+// a group consumer. It runs on the goroutine polling the consumer, before the
+// assignment changes.
+type RebalanceHandler func(event *RebalanceEvent) error
+
+// RebalanceEvent describes one change of a group consumer's assignment.
+type RebalanceEvent struct {
+	// Type is "assigned" or "revoked".
+	Type       string
+	Partitions []TopicPartition
+	// Protocol is "eager" or "cooperative". Eager rebalances revoke and
+	// reassign every partition, cooperative ones only those that move.
+	Protocol string
+	// Lost is set when the partitions were revoked without the group's
+	// consent, e.g. after a session timeout. Their offsets can no longer be
+	// committed.
+	Lost bool
+
+	client     *ckafka.Consumer
+	partitions []ckafka.TopicPartition
+}
+
+func newRebalanceEvent(client *ckafka.Consumer, eventType string, partitions []ckafka.TopicPartition) *RebalanceEvent {
+	event := &RebalanceEvent{
+		Type:       eventType,
+		Partitions: topicPartitionsFromConfluent(partitions),
+		Protocol:   strings.ToLower(client.GetRebalanceProtocol()),
+		client:     client,
+		partitions: append([]ckafka.TopicPartition(nil), partitions...),
+	}
+	if eventType == rebalanceRevoked {
+		event.Lost = client.AssignmentLost()
+	}
+
+	return event
+}
+
+func topicPartitionsFromConfluent(partitions []ckafka.TopicPartition) []TopicPartition {
+	converted := make([]TopicPartition, 0, len(partitions))
+	for _, partition := range partitions {
+		topicPartition := TopicPartition{
+			Partition: int(partition.Partition),
+			Offset:    int64(partition.Offset),
+		}
+		if partition.Topic != nil {
+			topicPartition.Topic = *partition.Topic
+		}
+		converted = append(converted, topicPartition)
+	}
+
+	return converted
+}
+
+// Commit synchronously commits the offsets consumed so far. On revoke this is
+// the last chance to commit them before another member takes the partitions.
+func (e *RebalanceEvent) Commit() error {
+	if _, err := e.client.Commit(); err != nil {
+		var kafkaErr ckafka.Error
+		if errors.As(err, &kafkaErr) && kafkaErr.Code() == ckafka.ErrNoOffset {
+			return nil
+		}
+		return NewXk6KafkaError(failedCommitConsumer, "Failed to commit consumer offsets.", err)
+	}
+
+	return nil
+}
+
+// Seek sets the offset an assigned partition starts from. Partitions that are
+// not seeked start from their committed offset, or from startOffset.
+func (e *RebalanceEvent) Seek(partition TopicPartition) error {
+	if e.Type != rebalanceAssigned {
+		return newInvalidConfigError("rebalance seek", errRebalanceSeekRequiresAssigned)
+	}
+
+	for i := range e.partitions {
+		assigned := &e.partitions[i]
+		if assigned.Topic != nil && *assigned.Topic == partition.Topic && int(assigned.Partition) == partition.Partition {
+			assigned.Offset = ckafka.Offset(partition.Offset)
+			e.Partitions[i].Offset = partition.Offset
+			return nil
+		}
+	}
+
+	return newInvalidConfigError("rebalance seek", fmt.Errorf(
+		"%w: %s/%d", errRebalancePartitionNotAssigned, partition.Topic, partition.Partition))
+}
+
+// apply acknowledges the rebalance to librdkafka, which waits for it before
+// fetching from the new assignment or handing the revoked partitions over.
+func (e *RebalanceEvent) apply() error {
+	cooperative := e.Protocol == rebalanceProtocolCooperative
+
+	switch {
+	case e.Type == rebalanceAssigned && cooperative:
+		return e.client.IncrementalAssign(e.partitions)
+	case e.Type == rebalanceAssigned:
+		return e.client.Assign(e.partitions)
+	case cooperative:
+		return e.client.IncrementalUnassign(e.partitions)
+	default:
+		return e.client.Unassign()
+	}
+}
+
+// rebalance is the rebalance callback of group consumers. librdkafka invokes
+// it from Poll, so the handler runs on the goroutine that consumes.
+func (c *Consumer) rebalance(client *ckafka.Consumer, event ckafka.Event) error {
+	var rebalanceEvent *RebalanceEvent
+	switch e := event.(type) {
+	case ckafka.AssignedPartitions:
+		rebalanceEvent = newRebalanceEvent(client, rebalanceAssigned, e.Partitions)
+	case ckafka.RevokedPartitions:
+		rebalanceEvent = newRebalanceEvent(client, rebalanceRevoked, e.Partitions)
+	default:
+		return nil
+	}
+
+	var handlerErr error
+	if rebalanceEvent.Type == rebalanceAssigned {
+		handlerErr = c.applyStartTime(client, rebalanceEvent.Partitions, rebalanceEvent.partitions)
+		for i, partition := range rebalanceEvent.partitions {
+			rebalanceEvent.Partitions[i].Offset = int64(partition.Offset)
+		}
+	}
+	if handlerErr == nil && c.onRebalance != nil {
+		c.setInRebalance(true)
+		handlerErr = c.onRebalance(rebalanceEvent)
+		c.setInRebalance(false)
+	}
+
+	// The assignment must change even if the handler failed, otherwise the
+	// consumer stops fetching and cannot leave the group.
+	err := rebalanceEvent.apply()
+	if err == nil && rebalanceEvent.Type == rebalanceAssigned {
+		err = c.pauseAgain(client, rebalanceEvent.partitions)
+	}
+	if err != nil {
+		logger.WithField("error", err).Error("Failed to apply consumer rebalance.")
+		handlerErr = errors.Join(handlerErr, err)
+	}
+
+	c.rebalances.record(rebalanceEvent.Type, time.Now())
+	if handlerErr != nil {
+		c.rebalances.fail(NewXk6KafkaError(failedRebalance, "Failed to handle consumer rebalance.", handlerErr))
+	}
+
+	return nil
+}
+
+func (c *Consumer) setInRebalance(inRebalance bool) {
+	c.mu.Lock()
+	c.inRebalance = inRebalance
+	c.mu.Unlock()
+}
+
+// rebalanceTracker counts the rebalances of a group consumer and times them,
+// from the revoke, or the subscription for the first one, to the next
+// assignment. A rebalance is counted once it completes with an assignment,
+// so the revoke of an eager rebalance is not counted separately. They are kept
+// until the next consume call reports them.
+type rebalanceTracker struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	count     int
+	durations []time.Duration
+	err       error
+}
+
+func (t *rebalanceTracker) start(now time.Time) {
+	t.mu.Lock()
+	t.startedAt = now
+	t.mu.Unlock()
+}
+
+func (t *rebalanceTracker) record(eventType string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if eventType == rebalanceRevoked {
+		if t.startedAt.IsZero() {
+			t.startedAt = now
+		}
+		return
+	}
+
+	t.count++
+	if !t.startedAt.IsZero() {
+		t.durations = append(t.durations, now.Sub(t.startedAt))
+		t.startedAt = time.Time{}
+	}
+}
+
+func (t *rebalanceTracker) fail(err error) {
+	t.mu.Lock()
+	if t.err == nil {
+		t.err = err
+	}
+	t.mu.Unlock()
+}
+
+// take returns the number and the durations of the rebalances completed
+// since the last call.
+func (t *rebalanceTracker) take() (int, []time.Duration) {
+	if t == nil {
+		return 0, nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count, durations := t.count, t.durations
+	t.count, t.durations = 0, nil
+
+	return count, durations
+}
+
+// takeError returns the first error of a rebalance handler since the last
+// call.
+func (t *rebalanceTracker) takeError() error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := t.err
+	t.err = nil
+
+	return err
+}
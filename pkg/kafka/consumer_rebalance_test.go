@@ -0,0 +1,127 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebalanceTrackerTimesRebalances(t *testing.T) {
+	t.Parallel()
+
+	tracker := &rebalanceTracker{}
+	subscribedAt := time.Now()
+	tracker.start(subscribedAt)
+	tracker.record(rebalanceAssigned, subscribedAt.Add(2*time.Second))
+
+	// The revoke starts the next rebalance, which is counted once it
+	// completes.
+	revokedAt := subscribedAt.Add(time.Minute)
+	tracker.record(rebalanceRevoked, revokedAt)
+	count, durations := tracker.take()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []time.Duration{2 * time.Second}, durations)
+
+	// A cooperative member can be assigned more partitions without a revoke.
+	tracker.record(rebalanceAssigned, revokedAt.Add(time.Second))
+	tracker.record(rebalanceAssigned, revokedAt.Add(time.Hour))
+	count, durations = tracker.take()
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []time.Duration{time.Second}, durations)
+
+	tracker.fail(errRebalanceSeekRequiresAssigned)
+	tracker.fail(errRebalancePartitionNotAssigned)
+	assert.ErrorIs(t, tracker.takeError(), errRebalanceSeekRequiresAssigned)
+	assert.NoError(t, tracker.takeError())
+}
+
+func TestConsumerClassCallsRebalanceHandler(t *testing.T) {
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-rebalance-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(ctx, []Message{
+		{Value: []byte("0")}, {Value: []byte("1")}, {Value: []byte("2")}, {Value: []byte("3")}, {Value: []byte("4")},
+	}))
+
+	onRebalance, err := test.rt.RunString(`
+		var rebalances = [];
+		(function (event) {
+			rebalances.push(event.type + ":" + event.partitions.length + ":" + event.protocol);
+			if (event.type === "assigned") {
+				event.seek({ topic: event.partitions[0].topic, partition: 0, offset: 3 });
+			} else {
+				event.commit();
+			}
+		})`)
+	require.NoError(t, err)
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":     []string{mockCluster.BootstrapServers()},
+			"groupId":     "consumer-rebalance-group",
+			"groupTopics": []string{topicName},
+			"startOffset": firstOffset,
+			"maxWait":     "10s",
+			"onRebalance": onRebalance,
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	messages := consume(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})},
+	}).Export().([]map[string]any)
+	require.Len(t, messages, 1)
+	assert.Equal(t, int64(3), messages[0]["offset"])
+
+	metricsValues := test.getMetricValues()
+	assert.Equal(t, 1.0, metricsValues[test.module.metrics.ReaderRebalances.Name])
+	assert.Contains(t, metricsValues, test.module.metrics.ReaderRebalanceTime.Name)
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+
+	assert.Equal(t,
+		[]any{"assigned:1:eager", "revoked:1:eager"},
+		test.rt.Get("rebalances").Export(),
+	)
+}
+
+func TestConsumerClassRejectsInvalidRebalanceHandler(t *testing.T) {
+	test := getTestModuleInstance(t)
+
+	requireGoErrorMessage(t, func() {
+		test.module.consumerClass(sobek.ConstructorCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+				"brokers":     []string{"localhost:9092"},
+				"groupId":     "consumer-rebalance-group",
+				"groupTopics": []string{"topic"},
+				"onRebalance": "not a function",
+			})},
+		})
+	}, "Invalid reader config, OriginalError: onRebalance must be a function")
+}
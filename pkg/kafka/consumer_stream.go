@@ -0,0 +1,269 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+const (
+	defaultStreamMaxMessages = 100
+	defaultStreamMaxWait     = 100 * time.Millisecond
+	defaultStreamBufferSize  = 4
+)
+
+// StreamConfig configures a consumer started with Start.
+type StreamConfig struct {
+	// MaxMessages is the largest batch handed to the handler.
+	MaxMessages int `json:"maxMessages"`
+	// MaxWait is how long a batch collects messages before it is handed over
+	// with fewer than MaxMessages.
+	MaxWait Duration `json:"maxWait"`
+	// BufferSize is the number of batches waiting for the handler before the
+	// poll loop stops reading.
+	BufferSize    int  `json:"bufferSize"`
+	NanoPrecision bool `json:"nanoPrecision"`
+}
+
+type messageBatch struct {
+	messages []Message
+	elapsed  time.Duration
+}
+
+// messageStream is the background poll loop of a started consumer.
+type messageStream struct {
+	config  StreamConfig
+	batches chan messageBatch
+	// stop ends polling, while the batches read so far are still delivered.
+	// abort also drops the undelivered batches, and ends aborted.
+	stop    context.CancelFunc
+	abort   context.CancelFunc
+	aborted context.Context
+	done    chan struct{}
+	err     error
+}
+
+// startStream polls the consumer on a background goroutine and hands the
+// messages to the returned stream in batches, until stopStream or Close is
+// called. consume cannot be called meanwhile. A rebalance handler would run on
+// the background goroutine, so consumers with one cannot be started.
+func (c *Consumer) startStream(ctx context.Context, config StreamConfig) (*messageStream, error) {
+	if c == nil {
+		return nil, newMissingConfigError("consumer")
+	}
+	if c.onRebalance != nil {
+		return nil, newInvalidConfigError("consumer", errStreamRebalanceHandler)
+	}
+	if config.MaxMessages <= 0 {
+		config.MaxMessages = defaultStreamMaxMessages
+	}
+	if config.MaxWait.Duration <= 0 {
+		config.MaxWait = Duration{defaultStreamMaxWait}
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = defaultStreamBufferSize
+	}
+
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return nil, consumerReadError(err)
+		}
+		return nil, err
+	}
+
+	abortCtx, abort := context.WithCancel(ensureContext(ctx))
+	stopCtx, stop := context.WithCancel(abortCtx)
+	stream := &messageStream{
+		config:  config,
+		batches: make(chan messageBatch, config.BufferSize),
+		stop:    stop,
+		abort:   abort,
+		aborted: abortCtx,
+		done:    make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	if c.stream != nil {
+		c.mu.Unlock()
+		abort()
+		c.endOperation()
+		return nil, newInvalidConfigError("consumer", errStreamAlreadyStarted)
+	}
+	c.stream = stream
+	c.mu.Unlock()
+
+	go c.runStream(stopCtx, abortCtx, client, stream)
+
+	return stream, nil
+}
+
+// stopStream ends the poll loop of a started consumer. The batches it already
+// read are still delivered.
+func (c *Consumer) stopStream() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stream != nil {
+		c.stream.stop()
+	}
+}
+
+func (c *Consumer) runStream(stopCtx, abortCtx context.Context, client *ckafka.Consumer, stream *messageStream) {
+	defer func() {
+		c.mu.Lock()
+		c.stream = nil
+		c.mu.Unlock()
+
+		stream.abort()
+		close(stream.batches)
+		c.endOperation()
+		close(stream.done)
+	}()
+
+	for stopCtx.Err() == nil && !c.closeRequested() {
+		batch, err := c.readBatch(stopCtx, client, stream.config)
+		if len(batch.messages) > 0 {
+			select {
+			case stream.batches <- batch:
+			case <-abortCtx.Done():
+				return
+			}
+		}
+		if err != nil {
+			stream.err = err
+			return
+		}
+	}
+}
+
+// readBatch polls until it has config.MaxMessages messages, config.MaxWait
+// has passed or ctx is done.
+func (c *Consumer) readBatch(ctx context.Context, client *ckafka.Consumer, config StreamConfig) (messageBatch, error) {
+	startedAt := time.Now()
+	batchCtx, cancel := context.WithTimeout(ctx, config.MaxWait.Duration)
+	defer cancel()
+
+	messages := make([]Message, 0, config.MaxMessages)
+	for len(messages) < config.MaxMessages && !c.closeRequested() {
+		msg, err := c.consumerReadMessage(batchCtx, client)
+		if err != nil {
+			if consumerContextCause(batchCtx) != nil {
+				break
+			}
+			var kafkaErr ckafka.Error
+			if errors.As(err, &kafkaErr) && kafkaErr.IsTimeout() {
+				continue
+			}
+			return messageBatch{messages: messages, elapsed: time.Since(startedAt)}, consumerReadError(err)
+		}
+
+		if msg != nil {
+			message := confluentMessageToMessage(msg)
+			setHighWaterMark(client, &message)
+			keep, err := c.decode(&message)
+			if err != nil {
+				return messageBatch{messages: messages, elapsed: time.Since(startedAt)}, err
+			}
+			if keep {
+				messages = append(messages, message)
+			}
+		}
+	}
+
+	return messageBatch{messages: messages, elapsed: time.Since(startedAt)}, nil
+}
+
+// drain drops the remaining batches, so that an aborted poll loop waiting for
+// room in the buffer can exit.
+func (s *messageStream) drain() {
+	for batch := range s.batches {
+		logger.WithField("messages", len(batch.messages)).Debug("Dropping undelivered consumer batch.")
+	}
+}
+
+func (c *Consumer) started() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stream != nil
+}
+
+// abortStream drops the undelivered batches of a started consumer, so that
+// closing it does not wait for the VU to handle them.
+func (c *Consumer) abortStream() {
+	if c.stream != nil {
+		c.stream.abort()
+	}
+}
+
+// startWithConsumer starts consumer and calls handler with every batch on the
+// VU event loop, one batch at a time. The returned promise settles once the
+// consumer stops and the handler has seen every batch, and is rejected when
+// polling or the handler fails.
+func (k *Kafka) startWithConsumer(consumer *Consumer, handler sobek.Callable, config StreamConfig) *sobek.Promise {
+	runtime := k.vu.Runtime()
+	if state := k.vu.State(); state == nil {
+		logger.WithField("error", ErrForbiddenInInitContext).Error(ErrForbiddenInInitContext)
+		common.Throw(runtime, ErrForbiddenInInitContext)
+	}
+
+	stream, err := consumer.startStream(k.vu.Context(), config)
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	promise, resolve, reject := runtime.NewPromise()
+
+	// Only one callback is registered at a time, from the event loop, so the
+	// batches wait in the stream's buffer rather than in the event loop.
+	var deliverNext func()
+	deliverNext = func() {
+		callback := k.vu.RegisterCallback()
+		go func() {
+			batch, ok := <-stream.batches
+			callback(func() error {
+				// Batches read before the consumer was closed are dropped.
+				if ok && stream.aborted.Err() != nil {
+					go stream.drain()
+					ok = false
+				}
+				if !ok {
+					<-stream.done
+					if stream.err != nil {
+						k.reportConsumerCompatibilityMetrics(consumer, nil, 0, stream.err, false)
+						logger.WithField("error", stream.err).Error(stream.err)
+						return reject(runtime.NewGoError(stream.err))
+					}
+					return resolve(sobek.Undefined())
+				}
+
+				k.reportConsumerCompatibilityMetrics(consumer, batch.messages, batch.elapsed, nil, false)
+				messages := runtime.ToValue(messagesToJS(batch.messages, stream.config.NanoPrecision))
+				if _, err := handler(sobek.Undefined(), messages); err != nil {
+					stream.abort()
+					go stream.drain()
+					var exception *sobek.Exception
+					if errors.As(err, &exception) {
+						return reject(exception.Value())
+					}
+					return reject(runtime.NewGoError(err))
+				}
+
+				deliverNext()
+				return nil
+			})
+		}()
+	}
+	deliverNext()
+
+	return promise
+}
@@ -0,0 +1,235 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.k6.io/k6/metrics"
+)
+
+// runCallbacks runs the queued event loop callbacks until done returns true.
+func runCallbacks(t *testing.T, callbacks chan func() error, done func() bool) {
+	t.Helper()
+
+	for !done() {
+		select {
+		case callback := <-callbacks:
+			require.NoError(t, callback())
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "no event loop callback was queued")
+		}
+	}
+}
+
+func TestConsumerClassStartsAndStops(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+	callbacks := test.queueCallbacks()
+
+	topicName := "consumer-stream-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	for i := range 5 {
+		require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte(fmt.Sprint(i))}}))
+	}
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+			"topic":   topicName,
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	handler, err := test.rt.RunString(`
+		var offsets = [];
+		var batches = 0;
+		(function (messages) {
+			batches++;
+			messages.forEach(function (message) { offsets.push(message.offset); });
+		})
+	`)
+	require.NoError(t, err)
+
+	start := consumer.Get("start").Export().(func(sobek.FunctionCall) sobek.Value)
+	promise, ok := start(sobek.FunctionCall{
+		Arguments: []sobek.Value{handler, test.rt.ToValue(map[string]any{"maxMessages": 2})},
+	}).Export().(*sobek.Promise)
+	require.True(t, ok)
+
+	requireGoErrorMessage(t, func() {
+		start(sobek.FunctionCall{Arguments: []sobek.Value{handler}})
+	}, "Invalid consumer, OriginalError: consumer is already started")
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	requireGoErrorMessage(t, func() {
+		consume(sobek.FunctionCall{Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})}})
+	}, "Failed to consume message., OriginalError: consume cannot be called while the consumer is started")
+
+	runCallbacks(t, callbacks, func() bool {
+		return len(test.rt.Get("offsets").Export().([]any)) == 5
+	})
+	assert.Equal(t, []any{int64(0), int64(1), int64(2), int64(3), int64(4)}, test.rt.Get("offsets").Export())
+	assert.GreaterOrEqual(t, test.rt.Get("batches").ToInteger(), int64(3))
+	assert.Equal(t, sobek.PromiseStatePending, promise.State())
+
+	stop := consumer.Get("stop").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Same(t, promise, stop(sobek.FunctionCall{}).Export())
+	runCallbacks(t, callbacks, func() bool {
+		return promise.State() != sobek.PromiseStatePending
+	})
+	assert.Equal(t, sobek.PromiseStateFulfilled, promise.State())
+
+	consumed := 0.0
+	for _, sampleContainer := range metrics.GetBufferedSamples(test.samples) {
+		for _, sample := range sampleContainer.GetSamples() {
+			if sample.Metric == test.module.metrics.ReaderMessages {
+				consumed += sample.Value
+			}
+		}
+	}
+	assert.Equal(t, 5.0, consumed)
+
+	// A stopped consumer can be consumed from again.
+	require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte("5")}}))
+	messages := consume(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})},
+	}).Export().([]map[string]any)
+	require.Len(t, messages, 1)
+	assert.Equal(t, int64(5), messages[0]["offset"])
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
+
+func TestConsumerClassStartRejectsOnHandlerError(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+	callbacks := test.queueCallbacks()
+
+	topicName := "consumer-stream-error-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+		Topic:   topicName,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	require.NoError(t, producer.Produce(context.Background(), []Message{{Value: []byte("value")}}))
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+			"topic":   topicName,
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	handler, err := test.rt.RunString(`(function () { throw new Error("handler failed"); })`)
+	require.NoError(t, err)
+
+	start := consumer.Get("start").Export().(func(sobek.FunctionCall) sobek.Value)
+	promise := start(sobek.FunctionCall{Arguments: []sobek.Value{handler}}).Export().(*sobek.Promise)
+	runCallbacks(t, callbacks, func() bool {
+		return promise.State() != sobek.PromiseStatePending
+	})
+	assert.Equal(t, sobek.PromiseStateRejected, promise.State())
+	assert.Contains(t, promise.Result().String(), "handler failed")
+
+	// Closing waits for the poll loop to exit.
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
+
+func TestConsumerClassCloseDropsBufferedBatches(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+	callbacks := test.queueCallbacks()
+
+	topicName := "consumer-stream-close-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers: []string{mockCluster.BootstrapServers()},
+		Topic:   topicName,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	for i := range 5 {
+		require.NoError(t, producer.Produce(context.Background(), []Message{{Value: []byte(fmt.Sprint(i))}}))
+	}
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers": []string{mockCluster.BootstrapServers()},
+			"topic":   topicName,
+		})},
+	})
+	require.NotNil(t, consumer)
+	require.NoError(t, test.rt.Set("consumer", consumer))
+
+	handler, err := test.rt.RunString(`
+		var calls = 0;
+		(function () {
+			calls++;
+			consumer.close();
+		})
+	`)
+	require.NoError(t, err)
+
+	start := consumer.Get("start").Export().(func(sobek.FunctionCall) sobek.Value)
+	promise := start(sobek.FunctionCall{
+		Arguments: []sobek.Value{handler, test.rt.ToValue(map[string]any{"maxMessages": 1})},
+	}).Export().(*sobek.Promise)
+
+	// Let the poll loop buffer the other batches before the handler closes the
+	// consumer.
+	time.Sleep(time.Second)
+	runCallbacks(t, callbacks, func() bool {
+		return promise.State() != sobek.PromiseStatePending
+	})
+	assert.Equal(t, sobek.PromiseStateFulfilled, promise.State())
+	assert.Equal(t, int64(1), test.rt.Get("calls").ToInteger())
+}
+
+func TestConsumerStartRejectsRebalanceHandler(t *testing.T) {
+	t.Parallel()
+
+	consumer := &Consumer{onRebalance: func(*RebalanceEvent) error { return nil }}
+	_, err := consumer.startStream(context.Background(), StreamConfig{})
+	require.ErrorIs(t, err, errStreamRebalanceHandler)
+}
@@ -0,0 +1,172 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// OffsetsForTimes returns, for each partition, the earliest offset whose
+// message timestamp is at or after t, or -1 when there is none yet. Without
+// partitions, the current assignment is queried.
+func (c *Consumer) OffsetsForTimes(
+	ctx context.Context,
+	t time.Time,
+	partitions []TopicPartition,
+) ([]TopicPartition, error) {
+	if c == nil {
+		return nil, newMissingConfigError("consumer")
+	}
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return nil, NewXk6KafkaError(failedSetOffset, "Failed to query offsets for times.", err)
+		}
+		return nil, err
+	}
+	defer c.endOperation()
+
+	var query []ckafka.TopicPartition
+	if len(partitions) == 0 {
+		query, err = client.Assignment()
+		if err != nil {
+			return nil, NewXk6KafkaError(failedSetOffset, "Failed to read consumer assignment.", err)
+		}
+	} else {
+		query, err = confluentTopicPartitions(partitions, "", "offsets for times")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := offsetsAtTime(client, query, t, confluentMetadataTimeoutMs(ctx)); err != nil {
+		return nil, err
+	}
+
+	return topicPartitionsFromConfluent(query), nil
+}
+
+// SeekToTimestamp moves the consumer to the first message of partition whose
+// timestamp is at or after t, or to the end of the partition when there is
+// none.
+func (c *Consumer) SeekToTimestamp(ctx context.Context, partition int, t time.Time) error {
+	if c == nil {
+		return newMissingConfigError("consumer")
+	}
+	topic := c.assignedTopic()
+	if topic == "" {
+		return newInvalidConfigError("consumer", errSeekRequiresSingleConfiguredTopic)
+	}
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return NewXk6KafkaError(failedSetOffset, "Failed to seek consumer offset.", err)
+		}
+		return err
+	}
+	defer c.endOperation()
+
+	partitionValue, err := consumerPartition(partition, "partition")
+	if err != nil {
+		return err
+	}
+
+	query := []ckafka.TopicPartition{{Topic: &topic, Partition: partitionValue}}
+	if err := offsetsAtTime(client, query, t, confluentMetadataTimeoutMs(ctx)); err != nil {
+		return err
+	}
+
+	if err := client.Seek(query[0], -1); err != nil {
+		return NewXk6KafkaError(failedSetOffset, "Failed to seek consumer offset.", err)
+	}
+
+	return nil
+}
+
+// applyStartTime starts the partitions without an explicit offset from
+// startTime. Group consumers only do so the first time they are assigned a
+// partition, so that later rebalances resume from the committed offsets.
+func (c *Consumer) applyStartTime(
+	client *ckafka.Consumer,
+	assignments []TopicPartition,
+	partitions []ckafka.TopicPartition,
+) error {
+	if c.startTime.IsZero() {
+		return nil
+	}
+
+	query := make([]ckafka.TopicPartition, 0, len(partitions))
+	indexes := make([]int, 0, len(partitions))
+	for i, partition := range partitions {
+		if assignments[i].Offset > 0 {
+			continue
+		}
+		key := consumerMetricKey{topic: assignments[i].Topic, partition: assignments[i].Partition}
+		if _, ok := c.startTimeApplied[key]; c.group && ok {
+			continue
+		}
+		query = append(query, partition)
+		indexes = append(indexes, i)
+	}
+	if len(query) == 0 {
+		return nil
+	}
+
+	if err := offsetsAtTime(client, query, c.startTime, int(defaultConfluentTimeout.Milliseconds())); err != nil {
+		return err
+	}
+	for i, resolved := range query {
+		partitions[indexes[i]].Offset = resolved.Offset
+		if c.group {
+			c.startTimeApplied[consumerMetricKey{
+				topic:     assignments[indexes[i]].Topic,
+				partition: assignments[indexes[i]].Partition,
+			}] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// offsetsAtTime replaces the offsets of partitions with the offsets of the
+// first messages at or after t.
+func offsetsAtTime(client *ckafka.Consumer, partitions []ckafka.TopicPartition, t time.Time, timeoutMs int) error {
+	query := make([]ckafka.TopicPartition, len(partitions))
+	for i, partition := range partitions {
+		query[i] = partition
+		query[i].Offset = ckafka.Offset(t.UnixMilli())
+	}
+
+	resolved, err := client.OffsetsForTimes(query, timeoutMs)
+	if err != nil {
+		return NewXk6KafkaError(failedSetOffset, "Failed to query offsets for times.", err)
+	}
+
+	offsets := make(map[consumerMetricKey]ckafka.Offset, len(resolved))
+	for _, partition := range resolved {
+		if partition.Topic == nil {
+			continue
+		}
+		key := consumerMetricKey{topic: *partition.Topic, partition: int(partition.Partition)}
+		if partition.Error != nil {
+			return NewXk6KafkaError(failedSetOffset, "Failed to query offsets for times.",
+				fmt.Errorf("%s/%d: %w", key.topic, key.partition, partition.Error))
+		}
+		offsets[key] = partition.Offset
+	}
+
+	for i, partition := range partitions {
+		key := consumerMetricKey{topic: *partition.Topic, partition: int(partition.Partition)}
+		offset, ok := offsets[key]
+		if !ok {
+			return NewXk6KafkaError(failedSetOffset, "Failed to query offsets for times.",
+				fmt.Errorf("%w: %s/%d", errNoPositionsReturned, key.topic, key.partition))
+		}
+		partitions[i].Offset = offset
+	}
+
+	return nil
+}
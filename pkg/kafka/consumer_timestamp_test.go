@@ -0,0 +1,109 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTimeArgument(t *testing.T) {
+	test := getTestModuleInstance(t)
+
+	expected := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	date, err := test.rt.RunString(`new Date(Date.UTC(2024, 2, 1, 12))`)
+	require.NoError(t, err)
+
+	assert.True(t, expected.Equal(exportTimeArgument(test.rt, date, "timestamp")))
+	assert.True(t, expected.Equal(exportTimeArgument(test.rt, test.rt.ToValue("2024-03-01T12:00:00Z"), "timestamp")))
+	assert.True(t, expected.Equal(exportTimeArgument(test.rt, test.rt.ToValue(expected.UnixMilli()), "timestamp")))
+
+	requireGoErrorMessage(t, func() {
+		exportTimeArgument(test.rt, test.rt.ToValue(true), "timestamp")
+	}, "Invalid timestamp, OriginalError: expected Date, RFC 3339 string or epoch milliseconds, got bool")
+}
+
+// The mock cluster resolves every timestamp to the end of the partition, so
+// the test only uses times after the last message.
+func TestConsumerClassSeeksToTimestamp(t *testing.T) {
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "consumer-timestamp-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+	produce := func(value string) {
+		require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte(value), Time: time.Now()}}))
+	}
+	produce("0")
+	produce("1")
+
+	consumer := test.module.consumerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":   []string{mockCluster.BootstrapServers()},
+			"topic":     topicName,
+			"startTime": time.Now().Add(time.Minute).Format(time.RFC3339Nano),
+		})},
+	})
+	require.NotNil(t, consumer)
+
+	consume := consumer.Get("consume").Export().(func(sobek.FunctionCall) sobek.Value)
+	consumeOffset := func() int64 {
+		messages := consume(sobek.FunctionCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"maxMessages": 1})},
+		}).Export().([]map[string]any)
+		require.Len(t, messages, 1)
+		return messages[0]["offset"].(int64)
+	}
+
+	// startTime after the last message starts at the end of the partition.
+	produce("2")
+	assert.Equal(t, int64(2), consumeOffset())
+
+	offsetsForTimes := consumer.Get("offsetsForTimes").Export().(func(sobek.FunctionCall) sobek.Value)
+	future := time.Now().Add(time.Hour)
+	offsets := offsetsForTimes(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(future.UnixMilli())},
+	}).Export().([]map[string]any)
+	assert.Equal(t, []map[string]any{{"topic": topicName, "partition": 0, "offset": int64(ckafka.OffsetEnd)}}, offsets)
+
+	offsets = offsetsForTimes(sobek.FunctionCall{
+		Arguments: []sobek.Value{
+			test.rt.ToValue(future.Format(time.RFC3339Nano)),
+			test.rt.ToValue([]map[string]any{{"topic": topicName, "partition": 0}}),
+		},
+	}).Export().([]map[string]any)
+	assert.Len(t, offsets, 1)
+
+	// Seeking past the last message skips the unread ones.
+	produce("3")
+	seekToTimestamp := consumer.Get("seekToTimestamp").Export().(func(sobek.FunctionCall) sobek.Value)
+	seekToTimestamp(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(0), test.rt.ToValue(future.UnixMilli())},
+	})
+	produce("4")
+	assert.Equal(t, int64(4), consumeOffset())
+
+	closeConsumer := consumer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeConsumer(sobek.FunctionCall{}).Export())
+}
@@ -14,6 +14,7 @@ var (
 	errStubNoByVersionMap    = errors.New("stub: no byVersion map")
 	errStubUnknownSchemaVer  = errors.New("stub: unknown schema version")
 	errStubCreateSchemaUnsup = errors.New("stub: CreateSchema not implemented")
+	errStubGetByIDUnsup      = errors.New("stub: GetSchemaByID not implemented")
 )
 
 // stubSchemaRegistryClient implements SchemaRegistryClient for resolver tests.
@@ -47,6 +48,10 @@ func (s *stubSchemaRegistryClient) GetSchemaByVersion(subject string, version in
 	return nil, errStubUnknownSchemaVer
 }
 
+func (s *stubSchemaRegistryClient) GetSchemaByID(_ string, _ int) (*RegisteredSchema, error) {
+	return nil, errStubGetByIDUnsup
+}
+
 func (s *stubSchemaRegistryClient) CreateSchema(
 	_ string,
 	_ string,
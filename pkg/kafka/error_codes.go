@@ -21,6 +21,7 @@ const (
 	failedCreateProducer        errCode = 1010
 	failedFlushProducer         errCode = 1011
 	failedFreezeObject          errCode = 1012
+	producerClosed              errCode = 1013
 
 	// serdes errors.
 	invalidDataType            errCode = 2000
@@ -43,6 +44,10 @@ const (
 	failedParseStartOffset errCode = 3005
 	failedCreateConsumer   errCode = 3006
 	failedCommitConsumer   errCode = 3007
+	failedRebalance        errCode = 3008
+	failedAssignPartitions errCode = 3009
+	failedPausePartitions  errCode = 3010
+	failedDecodeMessage    errCode = 3011
 
 	// authentication.
 	failedCreateDialerWithScram    errCode = 4000
@@ -86,6 +91,30 @@ const (
 	failedReadPartitions    errCode = 6003
 	failedCreateAdminClient errCode = 6004
 	failedGetMetadata       errCode = 6005
+	failedDescribeConfigs   errCode = 6006
+	failedAlterConfigs      errCode = 6007
+	failedCreatePartitions  errCode = 6008
+	failedWaitPartitions    errCode = 6009
+	failedListGroups        errCode = 6010
+	failedDescribeGroups    errCode = 6011
+	failedDeleteGroups      errCode = 6012
+	failedListGroupOffsets  errCode = 6013
+	failedAlterGroupOffsets errCode = 6014
+	failedCreateACLs        errCode = 6015
+	failedDescribeACLs      errCode = 6016
+	failedDeleteACLs        errCode = 6017
+	failedDescribeCluster   errCode = 6018
+	failedDescribeTopics    errCode = 6019
+
+	// transactions.
+	failedInitTransactions         errCode = 7000
+	failedBeginTransaction         errCode = 7001
+	failedCommitTransaction        errCode = 7002
+	failedAbortTransaction         errCode = 7003
+	failedSendOffsetsToTransaction errCode = 7004
+	transactionAbortable           errCode = 7005
+	transactionFatal               errCode = 7006
+	transactionRetriable           errCode = 7007
 )
 
 var (
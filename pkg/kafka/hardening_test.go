@@ -105,20 +105,6 @@ func TestAdminClientClassRejectsMissingAddress(t *testing.T) {
 	}, "Invalid connection config, OriginalError: address must not be empty")
 }
 
-func TestWriterClassRejectsBalancerOnConfluentCompatibilityPath(t *testing.T) {
-	test := getTestModuleInstance(t)
-
-	requireGoErrorMessage(t, func() {
-		test.module.writerClass(sobek.ConstructorCall{
-			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
-				"brokers":  []string{"localhost:9092"},
-				"topic":    "test-topic",
-				"balancer": balancerRoundRobin,
-			})},
-		})
-	}, "Writer balancer configuration is not supported on the Confluent compatibility path.")
-}
-
 func TestSchemaRegistryClientClassRejectsMissingURL(t *testing.T) {
 	test := getTestModuleInstance(t)
 
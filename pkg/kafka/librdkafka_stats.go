@@ -0,0 +1,250 @@
+package kafka
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.k6.io/k6/lib"
+	"go.k6.io/k6/metrics"
+)
+
+// defaultStatisticsIntervalMs is how often producers and consumers emit
+// librdkafka statistics. Scripts can change it, or turn statistics off with 0,
+// through the raw "statistics.interval.ms" config key.
+const defaultStatisticsIntervalMs = 5000
+
+// librdkafkaQueuedMinMessages is librdkafka's default for
+// "queued.min.messages", the number of messages a consumer prefetches per
+// partition.
+const librdkafkaQueuedMinMessages = 100000
+
+// librdkafkaStats holds the parts of the librdkafka statistics JSON that are
+// reported as metrics. See STATISTICS.md in the librdkafka repository.
+type librdkafkaStats struct {
+	ClientID string                           `json:"client_id"`
+	Type     string                           `json:"type"`
+	MsgCnt   int64                            `json:"msg_cnt"`
+	Brokers  map[string]librdkafkaBrokerStats `json:"brokers"`
+	Topics   map[string]librdkafkaTopicStats  `json:"topics"`
+}
+
+type librdkafkaBrokerStats struct {
+	Name      string `json:"name"`
+	NodeID    int32  `json:"nodeid"`
+	Source    string `json:"source"`
+	Connects  int64  `json:"connects"`
+	TxRetries int64  `json:"txretries"`
+	// RTT is in microseconds, Throttle in milliseconds.
+	RTT      librdkafkaWindowStats `json:"rtt"`
+	Throttle librdkafkaWindowStats `json:"throttle"`
+}
+
+// librdkafkaWindowStats summarizes the values seen during one statistics
+// interval.
+type librdkafkaWindowStats struct {
+	Avg int64 `json:"avg"`
+	Cnt int64 `json:"cnt"`
+}
+
+type librdkafkaTopicStats struct {
+	Topic      string                              `json:"topic"`
+	BatchSize  librdkafkaWindowStats               `json:"batchsize"`
+	BatchCnt   librdkafkaWindowStats               `json:"batchcnt"`
+	Partitions map[string]librdkafkaPartitionStats `json:"partitions"`
+}
+
+type librdkafkaPartitionStats struct {
+	Partition   int32 `json:"partition"`
+	MsgqCnt     int64 `json:"msgq_cnt"`
+	XmitMsgqCnt int64 `json:"xmit_msgq_cnt"`
+	FetchqCnt   int64 `json:"fetchq_cnt"`
+	ConsumerLag int64 `json:"consumer_lag"`
+}
+
+// clientStats keeps the latest librdkafka statistics of a client until they
+// are reported. librdkafka emits them on the client's event goroutine, while
+// metrics are pushed from the VU goroutine on the next produce or consume call.
+type clientStats struct {
+	mu       sync.Mutex
+	latest   *librdkafkaStats
+	reported *librdkafkaStats
+}
+
+func (s *clientStats) update(statsJSON string) {
+	if s == nil {
+		return
+	}
+
+	var stats librdkafkaStats
+	if err := json.Unmarshal([]byte(statsJSON), &stats); err != nil {
+		logger.WithField("error", err).Warn("Failed to parse librdkafka statistics.")
+		return
+	}
+
+	s.mu.Lock()
+	s.latest = &stats
+	s.mu.Unlock()
+}
+
+// take returns the statistics received since the last call, or nil, along
+// with the ones reported before them so counters can be turned into deltas.
+func (s *clientStats) take() (current, previous *librdkafkaStats) {
+	if s == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.latest == nil {
+		return nil, s.reported
+	}
+
+	current, previous = s.latest, s.reported
+	s.reported, s.latest = current, nil
+
+	return current, previous
+}
+
+// received reports whether the client has emitted statistics at least once.
+func (s *clientStats) received() bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.latest != nil || s.reported != nil
+}
+
+// counterDelta turns a librdkafka counter into the increase since the previous
+// statistics. Counters restart from zero when a broker handle is recreated.
+func counterDelta(current, previous int64) float64 {
+	if current < previous {
+		return float64(current)
+	}
+
+	return float64(current - previous)
+}
+
+func (s *librdkafkaStats) connects() int64 {
+	if s == nil {
+		return 0
+	}
+
+	var total int64
+	for _, broker := range s.Brokers {
+		total += broker.Connects
+	}
+
+	return total
+}
+
+func (s *librdkafkaStats) txRetries() int64 {
+	if s == nil {
+		return 0
+	}
+
+	var total int64
+	for _, broker := range s.Brokers {
+		total += broker.TxRetries
+	}
+
+	return total
+}
+
+// fetchQueueLength returns the number of prefetched messages waiting for the
+// consumer in topic/partition.
+func (s *librdkafkaStats) fetchQueueLength(topic string, partition int) float64 {
+	if s == nil {
+		return 0
+	}
+
+	partitionStats, ok := s.Topics[topic].Partitions[strconv.Itoa(partition)]
+	if !ok {
+		return 0
+	}
+
+	return float64(partitionStats.FetchqCnt)
+}
+
+// reportClientStats pushes the broker, topic and partition metrics of a
+// librdkafka statistics event, tagged with the client ID.
+func (k *Kafka) reportClientStats(ctm metrics.TagsAndMeta, state *lib.State, current, previous *librdkafkaStats) {
+	if current == nil {
+		return
+	}
+
+	ctx := k.vu.Context()
+	now := time.Now()
+	clientTags := ctm.Tags.With("clientid", current.ClientID)
+	var samples []metrics.Sample
+	sample := func(metric *metrics.Metric, tags *metrics.TagSet, value float64) {
+		samples = append(samples, metrics.Sample{
+			Time:       now,
+			TimeSeries: metrics.TimeSeries{Metric: metric, Tags: tags},
+			Value:      value,
+			Metadata:   ctm.Metadata,
+		})
+	}
+
+	if current.Type == "producer" {
+		sample(k.metrics.ClientQueueMessages, clientTags, float64(current.MsgCnt))
+	}
+
+	for name, broker := range current.Brokers {
+		// Internal handles do not talk to a broker.
+		if broker.Source == "internal" {
+			continue
+		}
+
+		brokerTags := clientTags.With("broker", broker.Name)
+		if broker.RTT.Cnt > 0 {
+			sample(k.metrics.BrokerRTT, brokerTags, metrics.D(time.Duration(broker.RTT.Avg)*time.Microsecond))
+		}
+		if broker.Throttle.Cnt > 0 {
+			sample(k.metrics.BrokerThrottle, brokerTags, metrics.D(time.Duration(broker.Throttle.Avg)*time.Millisecond))
+		}
+
+		var previousRetries int64
+		if previous != nil {
+			previousRetries = previous.Brokers[name].TxRetries
+		}
+		sample(k.metrics.BrokerRetries, brokerTags, counterDelta(broker.TxRetries, previousRetries))
+	}
+
+	for _, topic := range current.Topics {
+		topicTags := clientTags.With("topic", topic.Topic)
+		if topic.BatchCnt.Cnt > 0 {
+			sample(k.metrics.TopicBatchSize, topicTags, float64(topic.BatchCnt.Avg))
+		}
+		if topic.BatchSize.Cnt > 0 {
+			sample(k.metrics.TopicBatchBytes, topicTags, float64(topic.BatchSize.Avg))
+		}
+
+		for _, partition := range topic.Partitions {
+			// Partition -1 holds messages not assigned to a partition yet.
+			if partition.Partition < 0 {
+				continue
+			}
+
+			partitionTags := topicTags.With("partition", strconv.Itoa(int(partition.Partition)))
+			queued := partition.MsgqCnt + partition.XmitMsgqCnt + partition.FetchqCnt
+			sample(k.metrics.PartitionQueueMessages, partitionTags, float64(queued))
+			// librdkafka reports -1 until both the committed offset and the
+			// high watermark are known.
+			if current.Type == "consumer" && partition.ConsumerLag >= 0 {
+				sample(k.metrics.PartitionLag, partitionTags, float64(partition.ConsumerLag))
+			}
+		}
+	}
+
+	metrics.PushIfNotDone(ctx, state.Samples, metrics.ConnectedSamples{
+		Samples: samples,
+		Tags:    clientTags,
+		Time:    now,
+	})
+}
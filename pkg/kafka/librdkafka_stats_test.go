@@ -0,0 +1,132 @@
+package kafka
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLibrdkafkaStatsJSON = `{
+	"client_id": "xk6-kafka",
+	"type": "consumer",
+	"brokers": {
+		"localhost:9092/1": {
+			"name": "localhost:9092/1", "nodeid": 1, "source": "learned",
+			"connects": %d, "txretries": %d,
+			"rtt": {"avg": 1500, "cnt": 3},
+			"throttle": {"avg": 0, "cnt": 0}
+		},
+		"GroupCoordinator": {"name": "GroupCoordinator", "nodeid": -1, "source": "internal", "connects": 7}
+	},
+	"topics": {
+		"orders": {
+			"topic": "orders",
+			"partitions": {
+				"0": {"partition": 0, "fetchq_cnt": 12, "consumer_lag": 40},
+				"-1": {"partition": -1, "fetchq_cnt": 0, "consumer_lag": -1}
+			}
+		}
+	}
+}`
+
+func TestClientStatsTakeReturnsDeltas(t *testing.T) {
+	t.Parallel()
+
+	stats := &clientStats{}
+	assert.False(t, stats.received())
+
+	current, previous := stats.take()
+	assert.Nil(t, current)
+	assert.Nil(t, previous)
+
+	stats.update(fmtStats(1, 2))
+	assert.True(t, stats.received())
+	current, previous = stats.take()
+	require.NotNil(t, current)
+	assert.Nil(t, previous)
+	assert.Equal(t, int64(8), current.connects())
+	assert.Equal(t, 12.0, current.fetchQueueLength("orders", 0))
+	assert.Equal(t, 0.0, current.fetchQueueLength("orders", 3))
+
+	stats.update(fmtStats(2, 5))
+	current, previous = stats.take()
+	require.NotNil(t, current)
+	require.NotNil(t, previous)
+	assert.Equal(t, 3.0, counterDelta(current.txRetries(), previous.txRetries()))
+
+	// Nothing new arrived since the last call.
+	current, previous = stats.take()
+	assert.Nil(t, current)
+	require.NotNil(t, previous)
+
+	stats.update("not json")
+	current, _ = stats.take()
+	assert.Nil(t, current)
+}
+
+func TestCounterDeltaHandlesReset(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 4.0, counterDelta(10, 6))
+	assert.Equal(t, 3.0, counterDelta(3, 6))
+}
+
+func TestProducerClassReportsLibrdkafkaStats(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	topicName := "librdkafka-stats-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer := test.module.producerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":      []string{mockCluster.BootstrapServers()},
+			"topic":        topicName,
+			"requiredAcks": -1,
+			"config":       map[string]any{"statistics.interval.ms": 100},
+		})},
+	})
+	require.NotNil(t, producer)
+
+	produce := producer.Get("produce").Export().(func(sobek.FunctionCall) sobek.Value)
+	produceOne := func() {
+		produce(sobek.FunctionCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+				"messages": []map[string]any{{"value": "stats-value"}},
+			})},
+		})
+	}
+
+	// The first statistics events can predate the topic metadata, so keep
+	// producing until a partition shows up in them.
+	metricsValues := make(map[string]float64)
+	require.Eventually(t, func() bool {
+		produceOne()
+		maps.Copy(metricsValues, test.getMetricValues())
+		_, ok := metricsValues[test.module.metrics.PartitionQueueMessages.Name]
+		return ok
+	}, 10*time.Second, 100*time.Millisecond)
+
+	closeProducer := producer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeProducer(sobek.FunctionCall{}).Export())
+
+	assert.Contains(t, metricsValues, test.module.metrics.BrokerRTT.Name)
+	assert.Contains(t, metricsValues, test.module.metrics.BrokerRetries.Name)
+	assert.Contains(t, metricsValues, test.module.metrics.ClientQueueMessages.Name)
+	assert.Contains(t, metricsValues, test.module.metrics.PartitionQueueMessages.Name)
+	assert.NotContains(t, metricsValues, test.module.metrics.PartitionLag.Name)
+}
+
+func fmtStats(connects, retries int) string {
+	return fmt.Sprintf(testLibrdkafkaStatsJSON, connects, retries)
+}
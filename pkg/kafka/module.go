@@ -160,6 +160,7 @@ func (m *Module) defineConstants() {
 	mustAddProp("GROUP_BALANCER_RANGE", groupBalancerRange)
 	mustAddProp("GROUP_BALANCER_ROUND_ROBIN", groupBalancerRoundRobin)
 	mustAddProp("GROUP_BALANCER_RACK_AFFINITY", groupBalancerRackAffinity)
+	mustAddProp("GROUP_BALANCER_COOPERATIVE_STICKY", groupBalancerCooperativeSticky)
 
 	// Isolation levels
 	mustAddProp("ISOLATION_LEVEL_READ_UNCOMMITTED", isolationLevelReadUncommitted)
@@ -189,6 +190,48 @@ func (m *Module) defineConstants() {
 	mustAddProp("SCHEMA_TYPE_JSON", Json)
 	mustAddProp("SCHEMA_TYPE_PROTOBUF", Protobuf)
 
+	// Decode error policies
+	mustAddProp("DECODE_ERROR_THROW", decodeErrorThrow)
+	mustAddProp("DECODE_ERROR_SKIP", decodeErrorSkip)
+	mustAddProp("DECODE_ERROR_RAW", decodeErrorRaw)
+
+	// Resource types
+	mustAddProp("RESOURCE_TOPIC", resourceTopic)
+	mustAddProp("RESOURCE_BROKER", resourceBroker)
+	mustAddProp("RESOURCE_GROUP", resourceGroup)
+	mustAddProp("RESOURCE_ANY", resourceAny)
+
+	// ACL resource pattern types
+	mustAddProp("PATTERN_TYPE_LITERAL", patternTypeLiteral)
+	mustAddProp("PATTERN_TYPE_PREFIXED", patternTypePrefixed)
+	mustAddProp("PATTERN_TYPE_MATCH", patternTypeMatch)
+	mustAddProp("PATTERN_TYPE_ANY", patternTypeAny)
+
+	// ACL operations
+	mustAddProp("ACL_OPERATION_ANY", aclOperationAny)
+	mustAddProp("ACL_OPERATION_ALL", aclOperationAll)
+	mustAddProp("ACL_OPERATION_READ", aclOperationRead)
+	mustAddProp("ACL_OPERATION_WRITE", aclOperationWrite)
+	mustAddProp("ACL_OPERATION_CREATE", aclOperationCreate)
+	mustAddProp("ACL_OPERATION_DELETE", aclOperationDelete)
+	mustAddProp("ACL_OPERATION_ALTER", aclOperationAlter)
+	mustAddProp("ACL_OPERATION_DESCRIBE", aclOperationDescribe)
+	mustAddProp("ACL_OPERATION_CLUSTER_ACTION", aclOperationClusterAction)
+	mustAddProp("ACL_OPERATION_DESCRIBE_CONFIGS", aclOperationDescribeConfigs)
+	mustAddProp("ACL_OPERATION_ALTER_CONFIGS", aclOperationAlterConfigs)
+	mustAddProp("ACL_OPERATION_IDEMPOTENT_WRITE", aclOperationIdempotentWrite)
+
+	// ACL permission types
+	mustAddProp("ACL_PERMISSION_ANY", aclPermissionAny)
+	mustAddProp("ACL_PERMISSION_ALLOW", aclPermissionAllow)
+	mustAddProp("ACL_PERMISSION_DENY", aclPermissionDeny)
+
+	// Incremental config operations
+	mustAddProp("CONFIG_OP_SET", configOpSet)
+	mustAddProp("CONFIG_OP_DELETE", configOpDelete)
+	mustAddProp("CONFIG_OP_APPEND", configOpAppend)
+	mustAddProp("CONFIG_OP_SUBTRACT", configOpSubtract)
+
 	// Time constants
 	mustAddProp("NANOSECOND", int64(time.Nanosecond))
 	mustAddProp("MICROSECOND", int64(time.Microsecond))
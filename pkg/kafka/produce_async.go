@@ -0,0 +1,132 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/common"
+)
+
+// ProduceAsync hands msgs to librdkafka and returns without waiting for
+// delivery. done is called from another goroutine once every message is
+// acknowledged, or with the first error. With withReports, done also gets
+// one delivery report per message. When maxInFlight calls are already
+// pending, ProduceAsync blocks until one of them completes.
+func (p *Producer) ProduceAsync(
+	ctx context.Context,
+	msgs []Message,
+	withReports bool,
+	done func(reports []DeliveryReport, err error),
+) error {
+	if p == nil || p.client == nil {
+		return newMissingConfigError("producer")
+	}
+	ctx = ensureContext(ctx)
+
+	select {
+	case p.inFlight <- struct{}{}:
+	case <-ctx.Done():
+		return NewXk6KafkaError(writerError, "Producer context cancelled.", ctx.Err())
+	}
+
+	wait := p.waitForAck || withReports
+	var deliveryChan chan ckafka.Event
+	var reports []DeliveryReport
+	if wait {
+		deliveryChan = make(chan ckafka.Event, len(msgs))
+		reports = make([]DeliveryReport, len(msgs))
+	}
+
+	if err := p.enqueue(ctx, msgs, deliveryChan, reports); err != nil {
+		<-p.inFlight
+		return err
+	}
+
+	go func() {
+		var err error
+		if wait {
+			err = p.awaitDeliveries(ctx, len(msgs), deliveryChan)
+		}
+		if err != nil || !withReports {
+			reports = nil
+		}
+		done(reports, err)
+		<-p.inFlight
+	}()
+
+	return nil
+}
+
+// waitForInFlight returns once no ProduceAsync call is pending, by taking
+// every in-flight slot and handing them back.
+func (p *Producer) waitForInFlight(ctx context.Context) error {
+	acquired := 0
+	defer func() {
+		for range acquired {
+			<-p.inFlight
+		}
+	}()
+
+	for acquired < cap(p.inFlight) {
+		select {
+		case p.inFlight <- struct{}{}:
+			acquired++
+		case <-ctx.Done():
+			return NewXk6KafkaError(failedFlushProducer, "Producer flush cancelled.", ctx.Err())
+		}
+	}
+
+	return nil
+}
+
+// produceAsyncWithProducer returns a promise that settles on the VU event loop
+// once the messages are delivered.
+func (k *Kafka) produceAsyncWithProducer(producer *Producer, produceConfig *ProduceConfig) *sobek.Promise {
+	runtime := k.vu.Runtime()
+	if producer == nil {
+		throwConfigError(runtime, newMissingConfigError("producer"))
+	}
+	if produceConfig == nil {
+		throwConfigError(runtime, newMissingConfigError("produce config"))
+	}
+
+	if state := k.vu.State(); state == nil {
+		logger.WithField("error", ErrForbiddenInInitContext).Error(ErrForbiddenInInitContext)
+		common.Throw(runtime, ErrForbiddenInInitContext)
+	}
+
+	ctx := k.vu.Context()
+	if ctx == nil {
+		err := NewXk6KafkaError(noContextError, "No context.", nil)
+		logger.WithField("error", err).Info(err)
+		common.Throw(runtime, err)
+	}
+
+	promise, resolve, reject := runtime.NewPromise()
+	callback := k.vu.RegisterCallback()
+
+	startedAt := time.Now()
+	settle := func(reports []DeliveryReport, err error) {
+		elapsed := time.Since(startedAt)
+		callback(func() error {
+			k.reportProducerCompatibilityMetrics(producer, produceConfig.Messages, elapsed, err)
+			if err != nil {
+				logger.WithField("error", err).Error(err)
+				return reject(runtime.NewGoError(err))
+			}
+			if produceConfig.DeliveryReports {
+				return resolve(deliveryReportsToJS(reports))
+			}
+			return resolve(sobek.Undefined())
+		})
+	}
+
+	err := producer.ProduceAsync(ctx, produceConfig.Messages, produceConfig.DeliveryReports, settle)
+	if err != nil {
+		settle(nil, err)
+	}
+
+	return promise
+}
@@ -0,0 +1,142 @@
+package kafka
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queueCallbacks replaces the VU event loop with a channel the test drains.
+func (k *kafkaTest) queueCallbacks() chan func() error {
+	callbacks := make(chan func() error, 16)
+	k.vu.RegisterCallbackField = func() func(func() error) {
+		return func(f func() error) {
+			callbacks <- f
+		}
+	}
+	return callbacks
+}
+
+func TestProducerClassProduceAsyncWithMockCluster(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+	callbacks := test.queueCallbacks()
+
+	topicName := "produce-async-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer := test.module.producerClass(sobek.ConstructorCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"brokers":     []string{mockCluster.BootstrapServers()},
+			"topic":       topicName,
+			"maxInFlight": 2,
+		})},
+	})
+	require.NotNil(t, producer)
+
+	produceAsync := producer.Get("produceAsync").Export().(func(sobek.FunctionCall) sobek.Value)
+	promise, ok := produceAsync(sobek.FunctionCall{
+		Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+			"messages": []map[string]any{{"value": "async-value"}},
+		})},
+	}).Export().(*sobek.Promise)
+	require.True(t, ok)
+	assert.Equal(t, sobek.PromiseStatePending, promise.State())
+
+	select {
+	case callback := <-callbacks:
+		require.NoError(t, callback())
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "produceAsync did not settle")
+	}
+	assert.Equal(t, sobek.PromiseStateFulfilled, promise.State())
+
+	closeProducer := producer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+	assert.Nil(t, closeProducer(sobek.FunctionCall{}).Export())
+
+	metricsValues := test.getMetricValues()
+	assert.Equal(t, 1.0, metricsValues[test.module.metrics.WriterMessages.Name])
+	assert.Equal(t, 0.0, metricsValues[test.module.metrics.WriterErrors.Name])
+}
+
+func TestProducerProduceAsyncBackpressureAndFlush(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	topicName := "produce-async-backpressure"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:     []string{mockCluster.BootstrapServers()},
+		Topic:       topicName,
+		MaxInFlight: 1,
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	release := make(chan struct{})
+	var settled atomic.Int32
+	first := []Message{{Value: []byte("first")}}
+	require.NoError(t, producer.ProduceAsync(ctx, first, false, func(_ []DeliveryReport, err error) {
+		assert.NoError(t, err)
+		<-release
+		settled.Add(1)
+	}))
+
+	// The only slot is held until the first call settles.
+	blockedCtx, blockedCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer blockedCancel()
+	second := []Message{{Value: []byte("second")}}
+	err = producer.ProduceAsync(blockedCtx, second, false, func([]DeliveryReport, error) {})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	third := []Message{{Value: []byte("third")}}
+	require.NoError(t, producer.ProduceAsync(ctx, third, true, func(reports []DeliveryReport, err error) {
+		assert.NoError(t, err)
+		assert.Len(t, reports, 1)
+		settled.Add(1)
+	}))
+
+	require.NoError(t, producer.Flush(ctx))
+	assert.Equal(t, int32(2), settled.Load())
+}
+
+func TestProducerAwaitDeliveriesAfterClose(t *testing.T) {
+	producer := &Producer{doneChan: make(chan struct{})}
+	close(producer.doneChan)
+
+	err := producer.awaitDeliveries(context.Background(), 1, make(chan ckafka.Event, 1))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errProducerClosed)
+
+	var xk6Err *Xk6KafkaError
+	require.ErrorAs(t, err, &xk6Err)
+	assert.Equal(t, producerClosed, xk6Err.Code)
+}
+
+func TestWriterConfigRejectsNegativeMaxInFlight(t *testing.T) {
+	var writerConfig WriterConfig
+	err := writerConfig.Parse(map[string]any{
+		"brokers":     []string{"localhost:9092"},
+		"maxInFlight": -1,
+	}, sobek.New())
+	require.ErrorIs(t, err, errMaxInFlightInvalid)
+}
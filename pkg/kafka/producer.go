@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -14,16 +15,37 @@ type ProducerStats struct {
 	Pending int
 }
 
-const producerFlushPollTimeoutMs = 100
+const (
+	producerFlushPollTimeoutMs = 100
+	defaultProducerMaxInFlight = 1000
+)
 
 type Producer struct {
-	client       *ckafka.Producer
-	config       ckafka.ConfigMap
-	defaultTopic string
-	waitForAck   bool
-	closeOnce    sync.Once
-	closeErr     error
-	doneChan     chan struct{}
+	client          *ckafka.Producer
+	config          ckafka.ConfigMap
+	defaultTopic    string
+	transactionalID string
+	waitForAck      bool
+	closeOnce       sync.Once
+	closeErr        error
+	doneChan        chan struct{}
+
+	txnMu        sync.Mutex
+	txnStartedAt time.Time
+
+	balancer     partitionBalancer
+	partitionsMu sync.Mutex
+	partitions   map[string]cachedTopicPartitions
+
+	// inFlight holds one slot per ProduceAsync call awaiting delivery.
+	inFlight chan struct{}
+
+	stats *clientStats
+
+	// keySerializer and valueSerializer are nil for keys and values passed
+	// as bytes or strings.
+	keySerializer   *messageSerializer
+	valueSerializer *messageSerializer
 }
 
 func NewProducerFromWriterConfig(writerConfig *WriterConfig) (*Producer, error) {
@@ -32,51 +54,142 @@ func NewProducerFromWriterConfig(writerConfig *WriterConfig) (*Producer, error)
 		return nil, err
 	}
 
+	var balancer partitionBalancer
+	switch {
+	case writerConfig == nil:
+	case writerConfig.BalancerFunc != nil:
+		balancer = keyFuncBalancer(writerConfig.BalancerFunc)
+	default:
+		balancer, err = newPartitionBalancer(writerConfig.Balancer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	saslContext, err := NewSaslContext(writerConfig.SASL, writerConfig.Brokers, SASLContextOpts{})
 	if err != nil {
 		return nil, err
 	}
 
+	var keySerializer, valueSerializer *messageSerializer
+	if writerConfig != nil {
+		if keySerializer, err = newMessageSerializer(Key, writerConfig.KeySerializer); err != nil {
+			return nil, err
+		}
+		if valueSerializer, err = newMessageSerializer(Value, writerConfig.ValueSerializer); err != nil {
+			keySerializer.close()
+			return nil, err
+		}
+	}
+
 	client, err := ckafka.NewProducer(&config)
 	if err != nil {
+		keySerializer.close()
+		valueSerializer.close()
 		return nil, NewXk6KafkaError(failedCreateProducer, "Failed to create producer.", err)
 	}
 
 	defaultTopic := ""
+	transactionalID := ""
+	maxInFlight := defaultProducerMaxInFlight
 	if writerConfig != nil {
 		defaultTopic = writerConfig.Topic
+		transactionalID = writerConfig.TransactionalID
+		if writerConfig.MaxInFlight > 0 {
+			maxInFlight = writerConfig.MaxInFlight
+		}
 	}
 
 	doneChan := make(chan struct{})
-	go handleProducerClientEvents(saslContext, client, client.Events(), doneChan)
+	stats := &clientStats{}
+	go handleProducerClientEvents(saslContext, client, client.Events(), doneChan, stats)
 
 	return &Producer{
-		client:       client,
-		config:       cloneConfluentConfigMap(config),
-		defaultTopic: defaultTopic,
-		waitForAck:   producerWaitsForAck(writerConfig),
-		doneChan:     doneChan,
+		client:          client,
+		config:          cloneConfluentConfigMap(config),
+		defaultTopic:    defaultTopic,
+		transactionalID: transactionalID,
+		waitForAck:      producerWaitsForAck(writerConfig),
+		doneChan:        doneChan,
+		balancer:        balancer,
+		inFlight:        make(chan struct{}, maxInFlight),
+		stats:           stats,
+		keySerializer:   keySerializer,
+		valueSerializer: valueSerializer,
 	}, nil
 }
 
+// DeliveryReport describes where the cluster stored a produced message.
+type DeliveryReport struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	// Time is the timestamp the message was sent with: the time set on the
+	// message or, like librdkafka does, the time it was produced. The Go
+	// client does not pass the broker's log append time through delivery
+	// reports.
+	Time time.Time
+	// Latency is the time from handing the message to librdkafka until its
+	// delivery report arrived.
+	Latency time.Duration
+
+	enqueuedAt time.Time
+}
+
 func (p *Producer) Produce(ctx context.Context, msgs []Message) error {
-	if p == nil || p.client == nil {
+	if p == nil {
 		return newMissingConfigError("producer")
 	}
+
+	_, err := p.produce(ctx, msgs, p.waitForAck)
+	return err
+}
+
+// ProduceWithReports is like Produce, but always waits for delivery and
+// returns one report per message, in the order of msgs.
+func (p *Producer) ProduceWithReports(ctx context.Context, msgs []Message) ([]DeliveryReport, error) {
+	return p.produce(ctx, msgs, true)
+}
+
+func (p *Producer) produce(ctx context.Context, msgs []Message, wait bool) ([]DeliveryReport, error) {
+	if p == nil || p.client == nil {
+		return nil, newMissingConfigError("producer")
+	}
 	if len(msgs) == 0 {
-		return nil
+		return nil, nil
 	}
 	ctx = ensureContext(ctx)
 	if err := ctx.Err(); err != nil {
-		return NewXk6KafkaError(writerError, "Producer context cancelled.", err)
+		return nil, NewXk6KafkaError(writerError, "Producer context cancelled.", err)
 	}
 
-	var deliveryChan chan ckafka.Event
-	if p.waitForAck {
-		deliveryChan = make(chan ckafka.Event, len(msgs))
+	if !wait {
+		return nil, p.enqueue(ctx, msgs, nil, nil)
 	}
 
-	for _, msg := range msgs {
+	deliveryChan := make(chan ckafka.Event, len(msgs))
+	reports := make([]DeliveryReport, len(msgs))
+	if err := p.enqueue(ctx, msgs, deliveryChan, reports); err != nil {
+		return nil, err
+	}
+	if err := p.awaitDeliveries(ctx, len(msgs), deliveryChan); err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
+
+// enqueue hands msgs to librdkafka. Partitions are picked here, on the
+// caller's goroutine, so script balancers never run on a delivery goroutine.
+// When reports is set, each message carries its report so awaitDeliveries
+// can fill it in.
+func (p *Producer) enqueue(
+	ctx context.Context,
+	msgs []Message,
+	deliveryChan chan ckafka.Event,
+	reports []DeliveryReport,
+) error {
+	for i, msg := range msgs {
 		if err := ctx.Err(); err != nil {
 			return NewXk6KafkaError(writerError, "Producer context cancelled.", err)
 		}
@@ -89,45 +202,109 @@ func (p *Producer) Produce(ctx context.Context, msgs []Message) error {
 			return newInvalidConfigError("producer message", errTopicMustNotBeEmpty)
 		}
 
+		partition, err := p.messagePartition(ctx, topic, msg)
+		if err != nil {
+			return err
+		}
+
 		kafkaMsg := &ckafka.Message{
 			TopicPartition: ckafka.TopicPartition{
 				Topic:     &topic,
-				Partition: ckafka.PartitionAny,
+				Partition: partition,
 			},
 			Key:       msg.Key,
 			Value:     msg.Value,
 			Timestamp: msg.Time,
 			Headers:   confluentHeaders(msg.Headers),
 		}
+		if reports != nil {
+			reports[i].enqueuedAt = time.Now()
+			reports[i].Time = msg.Time
+			if reports[i].Time.IsZero() {
+				reports[i].Time = reports[i].enqueuedAt.Truncate(time.Millisecond)
+			}
+			kafkaMsg.Opaque = &reports[i]
+		}
 
 		if err := p.produceMessage(ctx, kafkaMsg, deliveryChan); err != nil {
 			return NewXk6KafkaError(writerError, "Failed to produce message.", err)
 		}
 	}
 
-	if !p.waitForAck {
-		return nil
-	}
+	return nil
+}
 
-	for pending := len(msgs); pending > 0; pending-- {
+// awaitDeliveries waits for pending delivery reports on deliveryChan. Once the
+// producer is closed, reports that have not arrived yet are reported as lost.
+func (p *Producer) awaitDeliveries(ctx context.Context, pending int, deliveryChan chan ckafka.Event) error {
+	for ; pending > 0; pending-- {
+		var event ckafka.Event
 		select {
 		case <-ctx.Done():
 			return NewXk6KafkaError(writerError, "Producer context cancelled.", ctx.Err())
-		case event := <-deliveryChan:
-			switch produced := event.(type) {
-			case *ckafka.Message:
-				if produced.TopicPartition.Error != nil {
-					return NewXk6KafkaError(writerError, "Failed to deliver produced message.", produced.TopicPartition.Error)
-				}
-			case ckafka.Error:
-				return NewXk6KafkaError(writerError, "Producer reported an asynchronous error.", produced)
+		case event = <-deliveryChan:
+		case <-p.doneChan:
+			select {
+			case event = <-deliveryChan:
+			default:
+				return NewXk6KafkaError(
+					producerClosed, "Producer closed before the message was delivered.", errProducerClosed)
+			}
+		}
+
+		switch produced := event.(type) {
+		case *ckafka.Message:
+			if produced.TopicPartition.Error != nil {
+				return NewXk6KafkaError(writerError, "Failed to deliver produced message.", produced.TopicPartition.Error)
+			}
+			if report, ok := produced.Opaque.(*DeliveryReport); ok {
+				report.delivered(produced)
 			}
+		case ckafka.Error:
+			return NewXk6KafkaError(writerError, "Producer reported an asynchronous error.", produced)
 		}
 	}
 
 	return nil
 }
 
+func (r *DeliveryReport) delivered(msg *ckafka.Message) {
+	if msg.TopicPartition.Topic != nil {
+		r.Topic = *msg.TopicPartition.Topic
+	}
+	r.Partition = msg.TopicPartition.Partition
+	r.Offset = int64(msg.TopicPartition.Offset)
+	r.Latency = time.Since(r.enqueuedAt)
+}
+
+// messagePartition honors an explicit message partition, then the configured
+// balancer, and otherwise leaves the choice to librdkafka's partitioner.
+func (p *Producer) messagePartition(ctx context.Context, topic string, msg Message) (int32, error) {
+	if msg.partitionSet {
+		return consumerPartition(msg.Partition, "producer message")
+	}
+	if p.balancer == nil {
+		return ckafka.PartitionAny, nil
+	}
+
+	partitions, err := p.topicPartitions(ctx, topic)
+	if err != nil {
+		return 0, err
+	}
+
+	msg.Topic = topic
+	partition := p.balancer.Balance(msg, partitions...)
+	if !slices.Contains(partitions, partition) {
+		return 0, NewXk6KafkaError(
+			writerError,
+			"Balancer returned an unknown partition.",
+			fmt.Errorf("%w: %d", errBalancerPartitionUnknown, partition),
+		)
+	}
+
+	return consumerPartition(partition, "producer message")
+}
+
 func (p *Producer) Flush(ctx context.Context) error {
 	if p == nil || p.client == nil {
 		return newMissingConfigError("producer")
@@ -142,7 +319,7 @@ func (p *Producer) Flush(ctx context.Context) error {
 		}
 
 		if remaining := p.client.Flush(producerFlushPollTimeoutMs); remaining == 0 {
-			return nil
+			return p.waitForInFlight(ctx)
 		}
 	}
 }
@@ -158,14 +335,22 @@ func (p *Producer) Close() error {
 			return
 		}
 
-		if !p.waitForAck {
+		if !p.waitForAck || len(p.inFlight) > 0 {
 			ctx, cancel := context.WithTimeout(context.Background(), defaultConfluentTimeout)
 			p.closeErr = p.Flush(ctx)
 			cancel()
 		}
 		p.client = nil
 		close(p.doneChan)
+
+		// Async calls still waiting for delivery give up once doneChan is
+		// closed, so their callbacks run before the client goes away.
+		ctx, cancel := context.WithTimeout(context.Background(), defaultConfluentTimeout)
+		_ = p.waitForInFlight(ctx)
+		cancel()
 		client.Close()
+		p.keySerializer.close()
+		p.valueSerializer.close()
 	})
 
 	return p.closeErr
@@ -220,7 +405,7 @@ func confluentHeaders(headers map[string]any) []ckafka.Header {
 }
 
 func producerWaitsForAck(writerConfig *WriterConfig) bool {
-	return writerConfig == nil || writerConfig.RequiredAcks != 0
+	return writerConfig == nil || writerConfig.RequiredAcks != 0 || writerConfig.TransactionalID != ""
 }
 
 func handleProducerClientEvents(
@@ -228,6 +413,7 @@ func handleProducerClientEvents(
 	client *ckafka.Producer,
 	eventChan chan ckafka.Event,
 	doneChan <-chan struct{},
+	stats *clientStats,
 ) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -249,9 +435,11 @@ func handleProducerClientEvents(
 				return
 			}
 
-			switch event.(type) {
+			switch e := event.(type) {
 			case ckafka.OAuthBearerTokenRefresh:
 				_ = refreshOAuthToken(ctx, saslContext, client)
+			case *ckafka.Stats:
+				stats.update(e.String())
 			default:
 				// Ignore other event types
 			}
@@ -68,3 +68,42 @@ func TestProducerStatsNilReceiver(t *testing.T) {
 	var p *Producer
 	assert.Equal(t, ProducerStats{}, p.Stats())
 }
+
+func TestProducerProduceWithReports(t *testing.T) {
+	t.Parallel()
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	topicName := "delivery-reports"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 2, 1))
+
+	p, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:      []string{mockCluster.BootstrapServers()},
+		Topic:        topicName,
+		RequiredAcks: -1,
+	})
+	require.NoError(t, err)
+	defer func() { _ = p.Close() }()
+
+	reports, err := p.ProduceWithReports(t.Context(), []Message{
+		{Value: []byte("a"), Partition: 1, partitionSet: true},
+		{Value: []byte("b"), Partition: 1, partitionSet: true},
+		{Value: []byte("c"), Partition: 0, partitionSet: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+
+	assert.Equal(t, topicName, reports[0].Topic)
+	assert.Equal(t, []int32{1, 1, 0}, []int32{reports[0].Partition, reports[1].Partition, reports[2].Partition})
+	assert.Equal(t, []int64{0, 1, 0}, []int64{reports[0].Offset, reports[1].Offset, reports[2].Offset})
+	for _, report := range reports {
+		assert.False(t, report.Time.IsZero())
+		assert.Positive(t, report.Latency)
+	}
+
+	converted := deliveryReportsToJS(reports[:1])
+	require.Len(t, converted, 1)
+	assert.Equal(t, int64(0), converted[0]["offset"])
+	assert.Contains(t, converted[0], "latency")
+}
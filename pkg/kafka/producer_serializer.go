@@ -0,0 +1,252 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// SerializerConfig encodes the keys or values of produced messages, so that
+// produce accepts them as plain objects.
+type SerializerConfig struct {
+	// SchemaType is one of the SCHEMA_TYPE_* constants.
+	SchemaType SchemaType `mapstructure:"schemaType"`
+	// SchemaRegistry is required for AVRO and PROTOBUF. JSON without it is
+	// encoded as plain JSON.
+	SchemaRegistry *SchemaRegistryConfig `mapstructure:"schemaRegistry"`
+	// Subject is the subject of the schema. Without it, the subject is
+	// derived from the topic of each message with SubjectNameStrategy.
+	Subject             string `mapstructure:"subject"`
+	SubjectNameStrategy string `mapstructure:"subjectNameStrategy"`
+	// Schema is registered with AutoRegister. The record name strategies
+	// also take the record name from it.
+	Schema string `mapstructure:"schema"`
+	// MessageName is the Protobuf message to encode, and the record name of
+	// the record name strategies.
+	MessageName string `mapstructure:"messageName"`
+	// AutoRegister registers Schema under the subject. Otherwise the latest
+	// version registered under the subject is used.
+	AutoRegister bool `mapstructure:"autoRegister"`
+}
+
+// messageSerializer encodes the keys or values of produced messages.
+type messageSerializer struct {
+	element Element
+	config  SerializerConfig
+	client  SchemaRegistryClient
+
+	mu sync.Mutex
+	// schemas holds the schemas already resolved by subject.
+	schemas map[string]*Schema
+	// references holds the referenced schemas resolved by name.
+	references map[string]*Schema
+}
+
+func newMessageSerializer(element Element, config *SerializerConfig) (*messageSerializer, error) {
+	if config == nil {
+		//nolint: nilnil // without a serializer keys and values must be bytes or strings
+		return nil, nil
+	}
+
+	component := string(element) + " serializer"
+	if config.SchemaType == "" {
+		return nil, newInvalidConfigError(component, errSchemaTypeMustNotBeEmpty)
+	}
+	if _, err := GetSerdes(config.SchemaType); err != nil {
+		return nil, newInvalidConfigError(component, err)
+	}
+
+	switch config.SubjectNameStrategy {
+	case "", TopicNameStrategy:
+	case RecordNameStrategy, TopicRecordNameStrategy:
+		if config.Subject == "" && config.Schema == "" && config.MessageName == "" {
+			return nil, newInvalidConfigError(component, errRecordNameRequired)
+		}
+	default:
+		return nil, newInvalidConfigError(component, errSubjectNameStrategyInvalid)
+	}
+	if config.AutoRegister && config.Schema == "" {
+		return nil, newInvalidConfigError(component, errSchemaMustNotBeEmpty)
+	}
+
+	serializer := &messageSerializer{
+		element:    element,
+		config:     *config,
+		schemas:    make(map[string]*Schema),
+		references: make(map[string]*Schema),
+	}
+
+	switch {
+	case config.SchemaRegistry != nil:
+		client, err := newSchemaRegistryClient(config.SchemaRegistry)
+		if err != nil {
+			return nil, err
+		}
+		serializer.client = client
+	case config.SchemaType == Avro || config.SchemaType == Protobuf || config.AutoRegister:
+		return nil, newInvalidConfigError(component, errSchemaRegistryRequired)
+	}
+
+	return serializer, nil
+}
+
+// serialize encodes data produced to topic, with the wire format prefix when
+// its schema comes from the schema registry.
+func (s *messageSerializer) serialize(topic string, data any) ([]byte, error) {
+	data, err := jsonValue(data)
+	if err != nil {
+		return nil, err
+	}
+
+	serde, err := GetSerdes(s.config.SchemaType)
+	if err != nil {
+		return nil, err
+	}
+	if s.client == nil || s.config.SchemaType == String || s.config.SchemaType == Bytes {
+		encoded, serdeErr := serde.Serialize(data, nil)
+		if serdeErr != nil {
+			return nil, serdeErr
+		}
+		return encoded, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schema, schemaErr := s.schema(topic)
+	if schemaErr != nil {
+		return nil, schemaErr
+	}
+	encoded, err := serde.Serialize(data, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.config.SchemaType == Protobuf {
+		runtime, runtimeErr := buildProtobufRuntime(schema)
+		if runtimeErr != nil {
+			return nil, runtimeErr
+		}
+		encoded, err = encodeProtobufWireFormat(encoded, schema.ID, runtime.indexes)
+	} else {
+		encoded, err = wireFormat(encoded, schema.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+// schema returns the schema of the subject for topic, from the registry the
+// first time. s.mu must be held.
+func (s *messageSerializer) schema(topic string) (*Schema, error) {
+	subject := s.config.Subject
+	if subject == "" {
+		var err *Xk6KafkaError
+		subject, err = subjectName(&SubjectNameConfig{
+			Schema:              s.config.Schema,
+			Topic:               topic,
+			Element:             s.element,
+			SubjectNameStrategy: s.config.SubjectNameStrategy,
+			MessageName:         s.config.MessageName,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if schema, ok := s.schemas[subject]; ok {
+		return schema, nil
+	}
+
+	var registered *RegisteredSchema
+	var err error
+	if s.config.AutoRegister {
+		registered, err = s.client.CreateSchema(subject, s.config.Schema, s.config.SchemaType)
+		if err != nil {
+			return nil, NewXk6KafkaError(schemaCreationFailed, "Failed to create schema.", err)
+		}
+	} else {
+		registered, err = s.client.GetLatestSchema(subject)
+		if err != nil {
+			return nil, NewXk6KafkaError(schemaNotFound, "Failed to get schema from schema registry", err)
+		}
+	}
+
+	schema := &Schema{
+		EnableCaching: true,
+		ID:            registered.ID(),
+		Version:       registered.Version(),
+		Schema:        registered.Schema(),
+		SchemaType:    registered.SchemaType(),
+		References:    registered.References(),
+		Subject:       subject,
+		MessageName:   s.config.MessageName,
+		resolver:      newSchemaResolver(s.client, s.references, true),
+	}
+	s.schemas[subject] = schema
+
+	return schema, nil
+}
+
+func (s *messageSerializer) close() {
+	if s != nil && s.client != nil {
+		_ = s.client.Close()
+	}
+}
+
+// jsonValue turns a value exported from JS into what decoding it from JSON
+// would give, which is what the serdes expect.
+func jsonValue(data any) (any, *Xk6KafkaError) {
+	switch data.(type) {
+	case string, []byte:
+		return data, nil
+	}
+
+	jsonBytes, err := toJSONBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(jsonBytes, &value); err != nil {
+		return nil, NewXk6KafkaError(failedUnmarshalJSON, "Failed to unmarshal JSON data", err)
+	}
+	return value, nil
+}
+
+// serializeMessages replaces the keys and values of the messages of a produce
+// call with their encoded bytes, using the serializers of the producer.
+func (p *Producer) serializeMessages(messages []any) error {
+	if p == nil || (p.keySerializer == nil && p.valueSerializer == nil) {
+		return nil
+	}
+
+	for i, message := range messages {
+		fields, ok := message.(map[string]any)
+		if !ok {
+			continue
+		}
+		topic := p.defaultTopic
+		if messageTopic, ok := fields["topic"].(string); ok && messageTopic != "" {
+			topic = messageTopic
+		}
+
+		for _, serializer := range []*messageSerializer{p.keySerializer, p.valueSerializer} {
+			if serializer == nil {
+				continue
+			}
+			data, ok := fields[string(serializer.element)]
+			if !ok || data == nil {
+				continue
+			}
+
+			encoded, err := serializer.serialize(topic, data)
+			if err != nil {
+				return NewXk6KafkaError(failedToEncode, fmt.Sprintf(
+					"Failed to serialize the %s of message %d.", serializer.element, i), err)
+			}
+			fields[string(serializer.element)] = encoded
+		}
+	}
+
+	return nil
+}
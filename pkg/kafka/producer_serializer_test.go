@@ -0,0 +1,127 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMessageSerializerValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	serializer, err := newMessageSerializer(Value, nil)
+	require.NoError(t, err)
+	assert.Nil(t, serializer)
+
+	tests := []struct {
+		name   string
+		config SerializerConfig
+		err    error
+	}{
+		{"missing schema type", SerializerConfig{}, errSchemaTypeMustNotBeEmpty},
+		{"unknown schema type", SerializerConfig{SchemaType: "XML"}, ErrUnknownSerdesType},
+		{
+			"unknown strategy",
+			SerializerConfig{SchemaType: Json, SubjectNameStrategy: "SubjectStrategy"},
+			errSubjectNameStrategyInvalid,
+		},
+		{
+			"record name strategy without record name",
+			SerializerConfig{SchemaType: Json, SubjectNameStrategy: RecordNameStrategy},
+			errRecordNameRequired,
+		},
+		{"auto register without schema", SerializerConfig{SchemaType: Json, AutoRegister: true}, errSchemaMustNotBeEmpty},
+		{"avro without registry", SerializerConfig{SchemaType: Avro}, errSchemaRegistryRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := newMessageSerializer(Value, &tt.config)
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestProducerClassSerializesMessages(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(1)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	test := getTestModuleInstance(t)
+	test.moveToVUCode()
+
+	topicName := "producer-serializer-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	newProducer := func(valueSerializer map[string]any) (*sobek.Object, func([]any)) {
+		valueSerializer["schemaType"] = Avro
+		valueSerializer["schemaRegistry"] = map[string]any{"url": "mock://producer-serializer"}
+		producer := test.module.producerClass(sobek.ConstructorCall{
+			Arguments: []sobek.Value{test.rt.ToValue(map[string]any{
+				"brokers":         []string{mockCluster.BootstrapServers()},
+				"topic":           topicName,
+				"requiredAcks":    -1,
+				"keySerializer":   map[string]any{"schemaType": String},
+				"valueSerializer": valueSerializer,
+			})},
+		})
+		require.NotNil(t, producer)
+
+		produce := producer.Get("produce").Export().(func(sobek.FunctionCall) sobek.Value)
+		return producer, func(messages []any) {
+			produce(sobek.FunctionCall{
+				Arguments: []sobek.Value{test.rt.ToValue(map[string]any{"messages": messages})},
+			})
+		}
+	}
+
+	// The first producer registers the schema under <topic>-value.
+	registering, produce := newProducer(map[string]any{
+		"autoRegister": true,
+		"schema":       avroSchemaForSRTests,
+	})
+	produce([]any{map[string]any{"key": "key-0", "value": map[string]any{"field": "first"}}})
+	// Every mock registry client has its own schemas, so the others are given
+	// the one the schema is registered with.
+	registry := registering.Get("This").Export().(*Producer).valueSerializer.client
+
+	// The second one uses the latest version of the subject.
+	latest, produce := newProducer(map[string]any{"subject": topicName + "-value"})
+	latest.Get("This").Export().(*Producer).valueSerializer.client = registry
+	produce([]any{map[string]any{"value": map[string]any{"field": "second"}}})
+	requireGoErrorMessage(t, func() {
+		produce([]any{map[string]any{"value": map[string]any{"unknown": 1}}})
+	}, "Failed to serialize the value of message 0., "+
+		"OriginalError: Failed to encode data into binary, OriginalError: avro: missing required field field")
+
+	consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+		Brokers:           []string{mockCluster.BootstrapServers()},
+		Topic:             topicName,
+		ValueDeserializer: &DeserializerConfig{SchemaType: Avro, SchemaRegistry: &SchemaRegistryConfig{URL: "mock://"}},
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, consumer.Close())
+	}()
+	consumer.valueDeserializer.client = registry
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	messages, err := consumer.Consume(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, []byte("key-0"), messages[0].Key)
+	assert.Equal(t, map[string]any{"field": "first"}, messages[0].decodedValue)
+	assert.Equal(t, map[string]any{"field": "second"}, messages[1].decodedValue)
+
+	for _, producer := range []*sobek.Object{registering, latest} {
+		closeProducer := producer.Get("close").Export().(func(sobek.FunctionCall) sobek.Value)
+		assert.Nil(t, closeProducer(sobek.FunctionCall{}).Export())
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
-	"fmt"
 	"maps"
 	"sort"
 	"strings"
@@ -105,50 +104,26 @@ func encodeProtobufMessageIndexes(indexes []int) []byte {
 	return buffer[:length]
 }
 
-func (k *Kafka) encodeProtobufWireFormat(data []byte, schemaID int, messageIndexes []int) []byte {
-	schemaIDBytes := make([]byte, MagicPrefixSize-1)
-	if schemaID < 0 || schemaID > int(^uint32(0)) {
-		err := NewXk6KafkaError(
-			invalidSchemaID,
-			fmt.Sprintf("Invalid schema id %d: must be within uint32 range", schemaID),
-			nil,
-		)
-		common.Throw(k.vu.Runtime(), err)
-		return nil
-	}
-	binary.BigEndian.PutUint32(schemaIDBytes, uint32(schemaID))
-
-	payload := make([]byte, 0, 1+len(schemaIDBytes)+len(messageIndexes)+len(data))
-	payload = append(payload, 0)
-	payload = append(payload, schemaIDBytes...)
-	payload = append(payload, encodeProtobufMessageIndexes(messageIndexes)...)
+func encodeProtobufWireFormat(data []byte, schemaID int, messageIndexes []int) ([]byte, *Xk6KafkaError) {
+	indexes := encodeProtobufMessageIndexes(messageIndexes)
+	payload := make([]byte, 0, len(indexes)+len(data))
+	payload = append(payload, indexes...)
 	payload = append(payload, data...)
-	return payload
+	return wireFormat(payload, schemaID)
 }
 
-func (k *Kafka) decodeProtobufWireFormat(message []byte) (int, []int, []byte, *Xk6KafkaError) {
-	if len(message) < MagicPrefixSize {
-		return 0, nil, nil, NewXk6KafkaError(
-			messageTooShort,
-			"Invalid message: message too short to contain schema id.",
-			nil,
-		)
-	}
-	if message[0] != 0 {
-		return 0, nil, nil, NewXk6KafkaError(
-			messageTooShort,
-			"Invalid message: invalid start byte.",
-			nil,
-		)
+func decodeProtobufWireFormat(message []byte) (int, []int, []byte, *Xk6KafkaError) {
+	schemaID, data, err := parseWireFormat(message)
+	if err != nil {
+		return 0, nil, nil, err
 	}
 
-	schemaID := int(binary.BigEndian.Uint32(message[1:5]))
-	bytesRead, indexes, err := parseProtobufMessageIndexes(message[5:])
+	bytesRead, indexes, err := parseProtobufMessageIndexes(data)
 	if err != nil {
 		return 0, nil, nil, err
 	}
 
-	return schemaID, indexes, message[5+bytesRead:], nil
+	return schemaID, indexes, data[bytesRead:], nil
 }
 
 func toMessageIndexes(descriptor protoreflect.Descriptor, count int) []int {
@@ -526,7 +501,12 @@ func (k *Kafka) serializeProtobuf(container *Container) []byte {
 		schemaID = 0
 	}
 
-	return k.encodeProtobufWireFormat(payload, schemaID, runtime.indexes)
+	encoded, encodeErr := encodeProtobufWireFormat(payload, schemaID, runtime.indexes)
+	if encodeErr != nil {
+		common.Throw(k.vu.Runtime(), encodeErr)
+		return nil
+	}
+	return encoded
 }
 
 func (k *Kafka) deserializeProtobuf(container *Container) any {
@@ -556,7 +536,7 @@ func (k *Kafka) deserializeProtobuf(container *Container) any {
 		return nil
 	}
 
-	_, indexes, payload, err := k.decodeProtobufWireFormat(data)
+	_, indexes, payload, err := decodeProtobufWireFormat(data)
 	if err != nil {
 		common.Throw(k.vu.Runtime(), err)
 		return nil
@@ -566,44 +546,46 @@ func (k *Kafka) deserializeProtobuf(container *Container) any {
 		return payload
 	}
 
-	fileDesc, parseErr := parseProtobufFileDescriptor(container.Schema)
-	if parseErr != nil {
-		common.Throw(k.vu.Runtime(), parseErr)
+	decoded, decodeErr := decodeProtobufPayload(container.Schema, indexes, payload)
+	if decodeErr != nil {
+		common.Throw(k.vu.Runtime(), decodeErr)
 		return nil
 	}
+	return decoded
+}
+
+// decodeProtobufPayload decodes the payload after the wire format prefix into a
+// JSON object, using the message of schema the indexes point to.
+func decodeProtobufPayload(schema *Schema, indexes []int, payload []byte) (map[string]any, *Xk6KafkaError) {
+	fileDesc, parseErr := parseProtobufFileDescriptor(schema)
+	if parseErr != nil {
+		return nil, parseErr
+	}
 
 	messageDesc, descErr := toMessageDescriptor(fileDesc, indexes)
 	if descErr != nil {
-		common.Throw(k.vu.Runtime(), descErr)
-		return nil
+		return nil, descErr
 	}
 
 	message := dynamicpb.NewMessage(messageDesc)
 	if unmarshalErr := proto.Unmarshal(payload, message); unmarshalErr != nil {
-		common.Throw(k.vu.Runtime(), NewXk6KafkaError(
+		return nil, NewXk6KafkaError(
 			failedToDecodeFromBinary,
 			"Failed to decode protobuf payload",
 			unmarshalErr,
-		))
-		return nil
+		)
 	}
 
 	jsonData, marshalErr := protojson.MarshalOptions{
 		UseProtoNames: false,
 	}.Marshal(message)
 	if marshalErr != nil {
-		common.Throw(k.vu.Runtime(), NewXk6KafkaError(
+		return nil, NewXk6KafkaError(
 			failedToDecodeFromBinary,
 			"Failed to convert protobuf payload to JSON object",
 			marshalErr,
-		))
-		return nil
+		)
 	}
 
-	decoded, mapErr := toMap(jsonData)
-	if mapErr != nil {
-		common.Throw(k.vu.Runtime(), mapErr)
-		return nil
-	}
-	return decoded
+	return toMap(jsonData)
 }
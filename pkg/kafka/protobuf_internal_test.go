@@ -370,7 +370,8 @@ func TestDeserializeProtobufParseAndPayloadFailures(t *testing.T) {
 	runtime, err := buildProtobufRuntime(validSchema)
 	require.Nil(t, err)
 
-	invalidPayloadWire := test.module.encodeProtobufWireFormat([]byte{255, 1, 2}, validSchema.ID, runtime.indexes)
+	invalidPayloadWire, err := encodeProtobufWireFormat([]byte{255, 1, 2}, validSchema.ID, runtime.indexes)
+	require.Nil(t, err)
 	requireGoErrorContains(t, func() {
 		test.module.deserializeProtobuf(&Container{
 			Data:           invalidPayloadWire,
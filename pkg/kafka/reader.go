@@ -14,9 +14,10 @@ import (
 
 var (
 	// Group balancers.
-	groupBalancerRange        = "group_balancer_range"
-	groupBalancerRoundRobin   = "group_balancer_round_robin"
-	groupBalancerRackAffinity = "group_balancer_rack_affinity"
+	groupBalancerRange             = "group_balancer_range"
+	groupBalancerRoundRobin        = "group_balancer_round_robin"
+	groupBalancerRackAffinity      = "group_balancer_rack_affinity"
+	groupBalancerCooperativeSticky = "group_balancer_cooperative_sticky"
 
 	// Isolation levels.
 	isolationLevelReadUncommitted = "isolation_level_read_uncommitted"
@@ -52,6 +53,7 @@ type ReaderConfig struct {
 	Brokers                []string      `json:"brokers"`
 	GroupTopics            []string      `json:"groupTopics"`
 	GroupBalancers         []string      `json:"groupBalancers"`
+	Rack                   string        `json:"rack"`
 	MaxWait                Duration      `json:"maxWait"`
 	ReadBatchTimeout       time.Duration `json:"readBatchTimeout"`
 	ReadLagInterval        time.Duration `json:"readLagInterval"`
@@ -67,6 +69,25 @@ type ReaderConfig struct {
 	OffsetOutOfRangeError  bool          `json:"offsetOutOfRangeError"` // deprecated, do not use
 	SASL                   SASLConfig    `json:"sasl"`
 	TLS                    TLSConfig     `json:"tls"`
+	// Config holds raw librdkafka properties that take precedence over the
+	// typed options above.
+	Config       map[string]any `json:"config"`
+	StrictConfig bool           `json:"strictConfig"`
+	// OnRebalance is called when the group assigns or revokes partitions.
+	OnRebalance RebalanceHandler `json:"-"`
+	// Assignments are the partitions a consumer without a group reads from,
+	// instead of Topic and Partition.
+	Assignments []TopicPartition `json:"assignments"`
+	// StartTime starts partitions without an explicit offset from their first
+	// message at or after it, instead of startOffset.
+	StartTime time.Time `json:"startTime"`
+	// KeyDeserializer and ValueDeserializer decode the keys and values of
+	// consumed messages before they are returned.
+	KeyDeserializer   *DeserializerConfig `json:"keyDeserializer"`
+	ValueDeserializer *DeserializerConfig `json:"valueDeserializer"`
+	// GroupInstanceID makes the consumer a static member of GroupID, which
+	// rejoins without a rebalance. "{vu}" in it is replaced with the VU id.
+	GroupInstanceID string `json:"groupInstanceId"`
 }
 
 type ConsumeConfig struct {
@@ -74,6 +95,10 @@ type ConsumeConfig struct {
 	MaxMessages   int64 `json:"maxMessages"`
 	NanoPrecision bool  `json:"nanoPrecision"`
 	ExpectTimeout bool  `json:"expectTimeout"`
+	// Filter drops messages before they are returned. Dropped messages do
+	// not count towards MaxMessages, so a filtered call reads for at most
+	// maxWait and then times out.
+	Filter *MessageFilter `json:"filter"`
 }
 
 type Duration struct {
@@ -130,7 +155,16 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 			readerConfigParams["groupId"] = groupID
 		}
 	}
+	// Functions cannot be decoded with the rest of the config.
+	onRebalance := call.Argument(0).ToObject(runtime).Get("onRebalance")
+	delete(readerConfigParams, "onRebalance")
 	decodeArgumentMap(runtime, readerConfigParams, &readerConfig, "reader config")
+	if onRebalance != nil && !sobek.IsUndefined(onRebalance) && !sobek.IsNull(onRebalance) {
+		readerConfig.OnRebalance = k.rebalanceHandler(onRebalance)
+	}
+	if readerConfig.GroupInstanceID != "" {
+		readerConfig.GroupInstanceID = expandGroupInstanceID(readerConfig.GroupInstanceID, k.vuID())
+	}
 
 	consumer, err := NewConsumerFromReaderConfig(&readerConfig)
 	if err != nil {
@@ -156,6 +190,40 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 		common.Throw(runtime, err)
 	}
 
+	// The promise of the last start call, returned by stop.
+	var streamPromise *sobek.Promise
+	err = consumerObject.Set("start", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+		handler, ok := sobek.AssertFunction(call.Argument(0))
+		if !ok {
+			throwConfigError(runtime, newInvalidConfigError("start", errStreamHandlerNotFunction))
+		}
+
+		var streamConfig StreamConfig
+		if len(call.Arguments) > 1 && !sobek.IsUndefined(call.Argument(1)) {
+			decodeArgument(runtime, call.Argument(1), &streamConfig, "stream config")
+		}
+
+		streamPromise = k.startWithConsumer(consumer, handler, streamConfig)
+		return runtime.ToValue(streamPromise)
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("stop", func(_ sobek.FunctionCall) sobek.Value {
+		consumer.stopStream()
+		if streamPromise == nil {
+			return sobek.Undefined()
+		}
+		return runtime.ToValue(streamPromise)
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
 	err = consumerObject.Set("seek", func(call sobek.FunctionCall) sobek.Value {
 		if len(call.Arguments) < consumerSeekArgumentCount {
 			common.Throw(runtime, ErrNotEnoughArguments)
@@ -179,6 +247,47 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 		common.Throw(runtime, err)
 	}
 
+	err = consumerObject.Set("seekToTimestamp", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) < consumerSeekArgumentCount {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		var partition int
+		if err := runtime.ExportTo(call.Argument(0), &partition); err != nil {
+			common.Throw(runtime, newInvalidConfigError("partition", err))
+		}
+		timestamp := exportTimeArgument(runtime, call.Argument(1), "timestamp")
+
+		if err := consumer.SeekToTimestamp(k.vu.Context(), partition, timestamp); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("offsetsForTimes", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		timestamp := exportTimeArgument(runtime, call.Argument(0), "timestamp")
+		var partitions []TopicPartition
+		if len(call.Arguments) > 1 && !sobek.IsUndefined(call.Argument(1)) {
+			decodeArgumentList(runtime, call.Argument(1), &partitions, "offsets for times")
+		}
+
+		offsets, err := consumer.OffsetsForTimes(k.vu.Context(), timestamp, partitions)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(topicPartitionsToJS(offsets))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
 	err = consumerObject.Set("position", func(call sobek.FunctionCall) sobek.Value {
 		if len(call.Arguments) == 0 {
 			common.Throw(runtime, ErrNotEnoughArguments)
@@ -199,8 +308,108 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 		common.Throw(runtime, err)
 	}
 
-	err = consumerObject.Set("commitOffsets", func(_ sobek.FunctionCall) sobek.Value {
-		if err := consumer.CommitOffsets(ensureContext(k.vu.Context())); err != nil {
+	err = consumerObject.Set("assign", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		var partitions []TopicPartition
+		decodeArgumentList(runtime, call.Argument(0), &partitions, "assign")
+		if err := consumer.Assign(partitions); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("incrementalAssign", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		var partitions []TopicPartition
+		decodeArgumentList(runtime, call.Argument(0), &partitions, "incremental assign")
+		if err := consumer.IncrementalAssign(partitions); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("unassign", func(call sobek.FunctionCall) sobek.Value {
+		var partitions []TopicPartition
+		if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Argument(0)) {
+			decodeArgumentList(runtime, call.Argument(0), &partitions, "unassign")
+		}
+		if err := consumer.Unassign(partitions); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("pause", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		var partitions []TopicPartition
+		decodeArgumentList(runtime, call.Argument(0), &partitions, "pause")
+		if err := consumer.Pause(partitions); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("resume", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		var partitions []TopicPartition
+		decodeArgumentList(runtime, call.Argument(0), &partitions, "resume")
+		if err := consumer.Resume(partitions); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("commitOffsets", func(call sobek.FunctionCall) sobek.Value {
+		var partitions []TopicPartition
+		if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Argument(0)) {
+			decodeArgumentList(runtime, call.Argument(0), &partitions, "commit offsets")
+		}
+		err := consumer.CommitOffsets(ensureContext(k.vu.Context()), partitions...)
+		k.reportConsumerCommits(consumer)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = consumerObject.Set("commitOffsetsAsync", func(call sobek.FunctionCall) sobek.Value {
+		var partitions []TopicPartition
+		if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Argument(0)) {
+			decodeArgumentList(runtime, call.Argument(0), &partitions, "commit offsets")
+		}
+		err := consumer.CommitOffsetsAsync(partitions...)
+		k.reportConsumerCommits(consumer)
+		if err != nil {
 			common.Throw(runtime, err)
 		}
 		return sobek.Undefined()
@@ -211,8 +420,19 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 
 	err = consumerObject.Set("stats", func(_ sobek.FunctionCall) sobek.Value {
 		stats := consumer.Stats()
+		paused := make([]map[string]any, 0, len(stats.Paused))
+		for _, partition := range stats.Paused {
+			paused = append(paused, map[string]any{
+				"topic":     partition.Topic,
+				"partition": partition.Partition,
+			})
+		}
 		return runtime.ToValue(map[string]any{
-			"assignments": stats.Assignments,
+			"assignments":     stats.Assignments,
+			"isolationLevel":  stats.IsolationLevel,
+			"paused":          paused,
+			"groupInstanceId": stats.GroupInstanceID,
+			"memberId":        stats.MemberID,
 			// Backward-compatible alias.
 			"Assignments": stats.Assignments,
 		})
@@ -222,7 +442,11 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 	}
 
 	err = consumerObject.Set("close", func(_ sobek.FunctionCall) sobek.Value {
-		if err := consumer.Close(); err != nil {
+		// Close waits for pending async commits, whose results are reported
+		// here since no consume call follows.
+		err := consumer.Close()
+		k.reportConsumerCommits(consumer)
+		if err != nil {
 			common.Throw(runtime, err)
 		}
 
@@ -239,6 +463,93 @@ func (k *Kafka) compatConsumerClass(call sobek.ConstructorCall) *sobek.Object {
 	return runtime.ToValue(consumerObject).ToObject(runtime)
 }
 
+// rebalanceHandler calls the script's onRebalance function with the event.
+// Errors thrown by the function fail the consume call that triggered the
+// rebalance.
+func (k *Kafka) rebalanceHandler(value sobek.Value) RebalanceHandler {
+	runtime := k.vu.Runtime()
+	handler, ok := sobek.AssertFunction(value)
+	if !ok {
+		throwConfigError(runtime, newInvalidConfigError("reader config", errRebalanceHandlerNotFunction))
+		return nil
+	}
+
+	return func(event *RebalanceEvent) error {
+		_, err := handler(sobek.Undefined(), k.rebalanceEventToJS(event))
+		return err
+	}
+}
+
+func (k *Kafka) rebalanceEventToJS(event *RebalanceEvent) *sobek.Object {
+	runtime := k.vu.Runtime()
+
+	eventObject := runtime.NewObject()
+	mustSet := func(name string, value any) {
+		if err := eventObject.Set(name, value); err != nil {
+			common.Throw(runtime, err)
+		}
+	}
+	mustSet("type", event.Type)
+	mustSet("partitions", topicPartitionsToJS(event.Partitions))
+	mustSet("protocol", event.Protocol)
+	mustSet("lost", event.Lost)
+	mustSet("commit", func(_ sobek.FunctionCall) sobek.Value {
+		if err := event.Commit(); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	mustSet("seek", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		var partition TopicPartition
+		decodeArgument(runtime, call.Argument(0), &partition, "rebalance seek")
+		if err := event.Seek(partition); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+
+	return eventObject
+}
+
+func topicPartitionsToJS(partitions []TopicPartition) []map[string]any {
+	converted := make([]map[string]any, 0, len(partitions))
+	for _, partition := range partitions {
+		converted = append(converted, map[string]any{
+			"topic":     partition.Topic,
+			"partition": partition.Partition,
+			"offset":    partition.Offset,
+		})
+	}
+
+	return converted
+}
+
+// exportConsumer returns the Go consumer backing a JS Consumer or Reader object.
+func exportConsumer(runtime *sobek.Runtime, value sobek.Value) *Consumer {
+	if sobek.IsUndefined(value) || sobek.IsNull(value) {
+		throwConfigError(runtime, newMissingConfigError("consumer"))
+		return nil
+	}
+
+	var consumer *Consumer
+	if this := value.ToObject(runtime).Get("This"); this != nil {
+		consumer, _ = this.Export().(*Consumer)
+	}
+	if consumer == nil {
+		throwConfigError(runtime, newInvalidConfigError(
+			"consumer",
+			fmt.Errorf("%w, got %T", errExpectedConsumer, value.Export()),
+		))
+		return nil
+	}
+
+	return consumer
+}
+
 func (c *ConsumeConfig) effectiveLimit() int {
 	if c == nil {
 		return 1
@@ -278,23 +589,41 @@ func (k *Kafka) consumeWithConsumer(
 		common.Throw(k.vu.Runtime(), err)
 	}
 
+	filter, filterErr := newMessageFilter(consumeConfig.Filter)
+	if filterErr != nil {
+		throwConfigError(k.vu.Runtime(), filterErr)
+	}
+
 	startedAt := time.Now()
 	startupGraceDeadline := startedAt.Add(consumerCompatibilityStartupGrace)
+	// maxWait only passes while the topic is idle, so on a busy topic a filter
+	// that drops every message would never return. A filtered call reads for
+	// at most maxWait instead.
+	filterDeadline := startedAt.Add(confluentConsumerMaxWait(consumer))
 	limit := consumeConfig.effectiveLimit()
+	// read holds every message read for the metrics, and messages the ones
+	// that passed the filter.
+	read := make([]Message, 0, limit)
 	messages := make([]Message, 0, limit)
+	filtered := make(map[consumerMetricKey]int)
 	for len(messages) < limit {
 		nextMessages, timedOut, shouldRetry, err := consumeConsumerCompatibilityBatch(
 			ctx,
 			consumer,
-			len(messages),
+			len(read),
 			startupGraceDeadline,
 		)
-		messages = append(messages, nextMessages...)
+		read = append(read, nextMessages...)
+		messages = append(messages, filter.filter(nextMessages, filtered)...)
+		if err == nil && filter != nil && len(messages) < limit && !time.Now().Before(filterDeadline) {
+			err, timedOut = consumerContextError(context.DeadlineExceeded), true
+		}
 		if err != nil {
 			if shouldRetry {
 				continue
 			}
-			k.reportConsumerCompatibilityMetrics(consumer, messages, time.Since(startedAt), err, timedOut)
+			k.reportConsumerCompatibilityMetrics(consumer, read, time.Since(startedAt), err, timedOut)
+			k.reportFilteredMessages(consumer, filtered)
 
 			if consumeConfig.ExpectTimeout && timedOut {
 				return messagesToJS(messages, consumeConfig.NanoPrecision)
@@ -311,7 +640,8 @@ func (k *Kafka) consumeWithConsumer(
 		startupGraceDeadline = time.Time{}
 	}
 
-	k.reportConsumerCompatibilityMetrics(consumer, messages, time.Since(startedAt), nil, false)
+	k.reportConsumerCompatibilityMetrics(consumer, read, time.Since(startedAt), nil, false)
+	k.reportFilteredMessages(consumer, filtered)
 
 	return messagesToJS(messages, consumeConfig.NanoPrecision)
 }
@@ -434,10 +764,14 @@ func messagesToJS(messages []Message, nanoPrecision bool) []map[string]any {
 			maps.Copy(headers, msg.Headers)
 		}
 
-		if len(msg.Key) > 0 {
+		if msg.decodedKey != nil {
+			message["key"] = msg.decodedKey
+		} else if len(msg.Key) > 0 {
 			message["key"] = msg.Key
 		}
-		if len(msg.Value) > 0 {
+		if msg.decodedValue != nil {
+			message["value"] = msg.decodedValue
+		} else if len(msg.Value) > 0 {
 			message["value"] = msg.Value
 		}
 
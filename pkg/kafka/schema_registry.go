@@ -87,6 +87,16 @@ func (k *Kafka) createResolverWithCache(
 	client SchemaRegistryClient,
 	cache map[string]*Schema,
 	enableCaching bool,
+) func(name string) (*Schema, error) {
+	return newSchemaResolver(client, cache, enableCaching)
+}
+
+// newSchemaResolver returns a function that resolves the schemas referenced by
+// name, from cache first and then from the schema registry.
+func newSchemaResolver(
+	client SchemaRegistryClient,
+	cache map[string]*Schema,
+	enableCaching bool,
 ) func(name string) (*Schema, error) {
 	return func(name string) (*Schema, error) {
 		// Try to find the referenced schema in the cache first
@@ -134,7 +144,7 @@ func (k *Kafka) createResolverWithCache(
 				SchemaType:    refSchemaInfo.SchemaType(),
 				References:    refSchemaInfo.References(),
 				Subject:       name,
-				resolver:      k.createResolverWithCache(client, cache, enableCaching), // Recursive resolver setup
+				resolver:      newSchemaResolver(client, cache, enableCaching), // Recursive resolver setup
 			}
 			if refSchema.EnableCaching {
 				cache[name] = refSchema
@@ -161,7 +171,7 @@ func (k *Kafka) createResolverWithCache(
 							SchemaType:    refSchemaInfo.SchemaType(),
 							References:    refSchemaInfo.References(),
 							Subject:       ref.Subject,
-							resolver:      k.createResolverWithCache(client, cache, enableCaching),
+							resolver:      newSchemaResolver(client, cache, enableCaching),
 						}
 						if refSchema.EnableCaching {
 							cache[ref.Subject] = refSchema
@@ -540,20 +550,32 @@ func (gt *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 // schemaRegistryClient creates a schemaRegistryClient instance
 // with the given configuration. It will also configure auth and TLS credentials if exists.
 func (k *Kafka) schemaRegistryClient(config *SchemaRegistryConfig) SchemaRegistryClient {
-	runtime := k.vu.Runtime()
-	if config == nil {
-		throwConfigError(runtime, newMissingConfigError("schema registry config"))
+	client, err := newSchemaRegistryClient(config)
+	if err != nil {
+		if err.Code == invalidConfiguration {
+			throwConfigError(k.vu.Runtime(), err)
+			return nil
+		}
+		common.Throw(k.vu.Runtime(), err)
 		return nil
 	}
+
+	return client
+}
+
+// newSchemaRegistryClient is schemaRegistryClient for callers outside of the
+// VU, which get the error back instead.
+func newSchemaRegistryClient(config *SchemaRegistryConfig) (SchemaRegistryClient, *Xk6KafkaError) {
+	if config == nil {
+		return nil, newMissingConfigError("schema registry config")
+	}
 	if config.URL == "" {
-		throwConfigError(runtime, newInvalidConfigError("schema registry config", errURLMustNotBeEmpty))
-		return nil
+		return nil, newInvalidConfigError("schema registry config", errURLMustNotBeEmpty)
 	}
 
 	tlsConfig, err := GetTLSConfig(config.TLS)
 	if err != nil && err.Code != noTLSConfig {
-		common.Throw(runtime, err)
-		return nil
+		return nil, err
 	}
 
 	httpClient := &http.Client{
@@ -573,15 +595,14 @@ func (k *Kafka) schemaRegistryClient(config *SchemaRegistryConfig) SchemaRegistr
 
 	srClient, clientErr := cschemaregistry.NewClient(clientConfig)
 	if clientErr != nil {
-		common.Throw(runtime, NewXk6KafkaError(
+		return nil, NewXk6KafkaError(
 			failedConfigureSchemaRegistryClient,
 			"Failed to configure the schema registry client",
 			clientErr,
-		))
-		return nil
+		)
 	}
 
-	return newConfluentSchemaRegistryAdapter(srClient, config.EnableCaching)
+	return newConfluentSchemaRegistryAdapter(srClient, config.EnableCaching), nil
 }
 
 func newSchemaRegistryTransport(tlsConfig *tls.Config) http.RoundTripper {
@@ -730,12 +751,23 @@ func (k *Kafka) getSubjectName(subjectNameConfig *SubjectNameConfig) string {
 		throwConfigError(k.vu.Runtime(), newMissingConfigError("subject name config"))
 		return ""
 	}
+
+	subject, err := subjectName(subjectNameConfig)
+	if err != nil {
+		common.Throw(k.vu.Runtime(), err)
+		return ""
+	}
+	return subject
+}
+
+// subjectName is getSubjectName for callers outside of the VU, which get the
+// error back instead.
+func subjectName(subjectNameConfig *SubjectNameConfig) (string, *Xk6KafkaError) {
 	if subjectNameConfig.SubjectNameStrategy == "" ||
 		subjectNameConfig.SubjectNameStrategy == TopicNameStrategy {
-		return subjectNameConfig.Topic + "-" + string(subjectNameConfig.Element)
+		return subjectNameConfig.Topic + "-" + string(subjectNameConfig.Element), nil
 	}
 
-	runtime := k.vu.Runtime()
 	recordName := ""
 	var schemaMap map[string]any
 	if strings.TrimSpace(subjectNameConfig.MessageName) != "" {
@@ -745,15 +777,13 @@ func (k *Kafka) getSubjectName(subjectNameConfig *SubjectNameConfig) string {
 	if recordName == "" {
 		err := json.Unmarshal([]byte(subjectNameConfig.Schema), &schemaMap)
 		if err != nil {
-			common.Throw(runtime, NewXk6KafkaError(
-				failedUnmarshalSchema, "Failed to unmarshal schema", err))
+			return "", NewXk6KafkaError(failedUnmarshalSchema, "Failed to unmarshal schema", err)
 		}
 		if namespace, ok := schemaMap["namespace"]; ok {
 			if namespace, ok := namespace.(string); ok {
 				recordName = namespace + "."
 			} else {
-				err := NewXk6KafkaError(failedTypeCast, "Failed to cast to string", nil)
-				common.Throw(runtime, err)
+				return "", NewXk6KafkaError(failedTypeCast, "Failed to cast to string", nil)
 			}
 		}
 	}
@@ -761,59 +791,70 @@ func (k *Kafka) getSubjectName(subjectNameConfig *SubjectNameConfig) string {
 		if name, ok := name.(string); ok {
 			recordName += name
 		} else {
-			err := NewXk6KafkaError(failedTypeCast, "Failed to cast to string", nil)
-			common.Throw(runtime, err)
+			return "", NewXk6KafkaError(failedTypeCast, "Failed to cast to string", nil)
 		}
 	}
 
 	if subjectNameConfig.SubjectNameStrategy == RecordNameStrategy {
-		return recordName
+		return recordName, nil
 	}
 	if subjectNameConfig.SubjectNameStrategy == TopicRecordNameStrategy {
-		return subjectNameConfig.Topic + "-" + recordName
+		return subjectNameConfig.Topic + "-" + recordName, nil
 	}
 
-	err := NewXk6KafkaError(failedToEncode, fmt.Sprintf(
+	return "", NewXk6KafkaError(failedToEncode, fmt.Sprintf(
 		"Unknown subject name strategy: %v", subjectNameConfig.SubjectNameStrategy), nil)
-	common.Throw(runtime, err)
-	return ""
 }
 
 // encodeWireFormat adds the proprietary 5-byte prefix to the Avro, ProtoBuf or
 // JSONSchema payload.
 // https://docs.confluent.io/platform/current/schema-registry/serdes-develop/index.html#wire-format
 func (k *Kafka) encodeWireFormat(data []byte, schemaID int) []byte {
+	encoded, err := wireFormat(data, schemaID)
+	if err != nil {
+		logger.WithField("error", err).Error(err)
+		common.Throw(k.vu.Runtime(), err)
+		return nil
+	}
+	return encoded
+}
+
+// wireFormat prefixes data with the magic byte and schema id.
+func wireFormat(data []byte, schemaID int) ([]byte, *Xk6KafkaError) {
 	schemaIDBytes := make([]byte, MagicPrefixSize-1)
 	// Validate schemaID is within uint32 range to prevent overflow
 	if schemaID < 0 || schemaID > int(^uint32(0)) {
-		err := NewXk6KafkaError(
+		return nil, NewXk6KafkaError(
 			invalidSchemaID,
 			fmt.Sprintf("Invalid schema id %d: must be within uint32 range", schemaID),
 			nil,
 		)
-		logger.WithField("error", err).Error(err)
-		common.Throw(k.vu.Runtime(), err)
-		return nil
 	}
 	binary.BigEndian.PutUint32(schemaIDBytes, uint32(schemaID))
-	return append(append([]byte{0}, schemaIDBytes...), data...)
+	return append(append([]byte{0}, schemaIDBytes...), data...), nil
 }
 
 // decodeWireFormat removes the proprietary 5-byte prefix from the Avro, ProtoBuf
 // or JSONSchema payload.
 // https://docs.confluent.io/platform/current/schema-registry/serdes-develop/index.html#wire-format
 func (k *Kafka) decodeWireFormat(message []byte) []byte {
-	runtime := k.vu.Runtime()
+	_, data, err := parseWireFormat(message)
+	if err != nil {
+		common.Throw(k.vu.Runtime(), err)
+		return nil
+	}
+	return data
+}
+
+// parseWireFormat splits the payload into the schema id of its prefix and
+// the data after it.
+func parseWireFormat(message []byte) (int, []byte, *Xk6KafkaError) {
 	if len(message) < MagicPrefixSize {
-		err := NewXk6KafkaError(messageTooShort,
+		return 0, nil, NewXk6KafkaError(messageTooShort,
 			"Invalid message: message too short to contain schema id.", nil)
-		common.Throw(runtime, err)
-		return nil
 	}
 	if message[0] != 0 {
-		err := NewXk6KafkaError(messageTooShort, "Invalid message: invalid start byte.", nil)
-		common.Throw(runtime, err)
-		return nil
+		return 0, nil, NewXk6KafkaError(messageTooShort, "Invalid message: invalid start byte.", nil)
 	}
-	return message[MagicPrefixSize:]
+	return int(binary.BigEndian.Uint32(message[1:MagicPrefixSize])), message[MagicPrefixSize:], nil
 }
@@ -5,6 +5,9 @@ import cschemaregistry "github.com/confluentinc/confluent-kafka-go/v2/schemaregi
 type SchemaRegistryClient interface {
 	GetLatestSchema(subject string) (*RegisteredSchema, error)
 	GetSchemaByVersion(subject string, version int) (*RegisteredSchema, error)
+	// GetSchemaByID returns the schema with the id of the wire format prefix.
+	// An empty subject looks the id up across all subjects.
+	GetSchemaByID(subject string, id int) (*RegisteredSchema, error)
 	CreateSchema(
 		subject string,
 		schema string,
@@ -67,6 +70,23 @@ func (a *confluentSchemaRegistryAdapter) GetSchemaByVersion(subject string, vers
 	), nil
 }
 
+func (a *confluentSchemaRegistryAdapter) GetSchemaByID(subject string, id int) (*RegisteredSchema, error) {
+	defer a.clearCachesIfDisabled()
+
+	schemaInfo, err := a.client.GetBySubjectAndID(subject, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRegisteredSchema(
+		id,
+		0,
+		schemaInfo.Schema,
+		schemaInfo.SchemaType,
+		schemaInfo.References,
+	), nil
+}
+
 func (a *confluentSchemaRegistryAdapter) CreateSchema(
 	subject string,
 	schema string,
@@ -3,27 +3,78 @@ package kafka
 import "errors"
 
 var (
+	errACLBindingMatchesAny                  = errors.New("only ACL binding filters can use *_ANY or PATTERN_TYPE_MATCH")
+	errACLBindingsMustNotBeEmpty             = errors.New("ACL bindings must not be empty")
+	errACLOperationInvalid                   = errors.New("operation must be an ACL_OPERATION_* constant")
+	errACLPatternTypeInvalid                 = errors.New("patternType must be a PATTERN_TYPE_* constant")
+	errACLPermissionTypeInvalid              = errors.New("permissionType must be an ACL_PERMISSION_* constant")
+	errACLPrincipalMustNotBeEmpty            = errors.New("principal must not be empty")
+	errACLResourceTypeInvalid                = errors.New("resourceType must be a RESOURCE_* constant")
 	errAddressMustNotBeEmpty                 = errors.New("address must not be empty")
+	errAssignmentRequiresNoGroup             = errors.New("partitions cannot be assigned manually to a group consumer")
+	errBalancerPartitionUnknown              = errors.New("balancer returned a partition the topic does not have")
 	errBrokersMustNotBeEmpty                 = errors.New("brokers must not be empty")
+	errCloseInRebalanceHandler               = errors.New("consumer cannot be closed from its rebalance handler")
+	errCommitOffsetNegative                  = errors.New("commit offset must not be negative")
+	errConsumerStarted                       = errors.New("consume cannot be called while the consumer is started")
+	errConfigKeyDenied                       = errors.New("config key is managed by xk6-kafka")
+	errConfigKeyUnknown                      = errors.New("unknown librdkafka config key")
+	errConfigOperationInvalid                = errors.New("operation must be a CONFIG_OP_* constant")
+	errConfigResourceTypeInvalid             = errors.New("type must be RESOURCE_TOPIC or RESOURCE_BROKER")
+	errConfigResourcesMustNotBeEmpty         = errors.New("config resources must not be empty")
+	errConfigValueInvalid                    = errors.New("config value must be a string, number or boolean")
+	errDecodeErrorPolicyInvalid              = errors.New("onError must be a DECODE_ERROR_* constant")
 	errEmptyTopicResultSet                   = errors.New("empty topic result set")
+	errExpectedArray                         = errors.New("expected array")
+	errExpectedConsumer                      = errors.New("expected Consumer object")
 	errExpectedObject                        = errors.New("expected object")
+	errExpectedTime                          = errors.New("expected Date, RFC 3339 string or epoch milliseconds")
+	errFilterTimestampRange                  = errors.New("timestampFrom must be before timestampTo")
+	errGroupBalancersMixed                   = errors.New("cooperative-sticky cannot be mixed with other balancers")
+	errGroupIDMustNotBeEmpty                 = errors.New("group id must not be empty")
+	errGroupInstanceIDRequiresGroup          = errors.New("groupInstanceId requires groupId")
+	errGroupOffsetsMustNotBeEmpty            = errors.New("group offsets must not be empty")
 	errGroupTopicsMustNotBeEmpty             = errors.New("groupTopics must not be empty")
+	errGroupsMustNotBeEmpty                  = errors.New("groups must not be empty")
+	errIsolationLevelInvalid                 = errors.New("isolationLevel must be an ISOLATION_LEVEL_* constant")
 	errNoPositionsReturned                   = errors.New("no positions returned")
 	errObjectMustNotBeNil                    = errors.New("object must not be nil")
+	errMaxInFlightInvalid                    = errors.New("maxInFlight must not be negative")
+	errPartitionCountInvalid                 = errors.New("partition count must be positive")
+	errPartitionNotAssigned                  = errors.New("partition is not assigned to the consumer")
 	errPartitionOutOfRange                   = errors.New("partition is out of int32 range")
+	errProducerClosed                        = errors.New("producer closed")
 	errPositionRequiresSingleConfiguredTopic = errors.New("position requires a single configured topic")
+	errRackRequired                          = errors.New("rack must be set to use GROUP_BALANCER_RACK_AFFINITY")
+	errRebalanceHandlerNotFunction           = errors.New("onRebalance must be a function")
+	errRecordNameRequired                    = errors.New("record name strategies need subject, schema or messageName")
+	errRebalancePartitionNotAssigned         = errors.New("partition is not being assigned")
+	errRebalanceSeekRequiresAssigned         = errors.New("seek is only allowed when partitions are assigned")
 	errReplicaAssignmentPartitionNegative    = errors.New("replica assignment partition must not be negative")
 	errReplicaAssignmentPartitionUnique      = errors.New("replica assignment partition must be unique")
 	errRequiredAcksInvalid                   = errors.New("requiredAcks must be one of -1, 0, or 1")
+	errResourceNameMustNotBeEmpty            = errors.New("resource name must not be empty")
 	errSchemaMustNotBeEmpty                  = errors.New("schema must not be empty")
+	errSchemaRegistryRequired                = errors.New("schemaRegistry must be set for AVRO, PROTOBUF or autoRegister")
 	errSchemaTypeMustNotBeEmpty              = errors.New("schemaType must not be empty")
 	errSeekRequiresSingleConfiguredTopic     = errors.New("seek requires a single configured topic")
+	errStreamAlreadyStarted                  = errors.New("consumer is already started")
+	errStreamHandlerNotFunction              = errors.New("handler must be a function")
+	errStreamRebalanceHandler                = errors.New("consumers with onRebalance cannot be started")
 	errStartOffsetInvalid                    = errors.New(
 		"startOffset must be FIRST_OFFSET, LAST_OFFSET, or a numeric offset",
 	)
-	errSubjectMustNotBeEmpty = errors.New("subject must not be empty")
-	errTopicMetadataNotFound = errors.New("topic metadata not found")
-	errTopicMustNotBeEmpty   = errors.New("topic must not be empty")
-	errUnknownBalancer       = errors.New("unknown balancer")
-	errURLMustNotBeEmpty     = errors.New("url must not be empty")
+	errSubjectMustNotBeEmpty            = errors.New("subject must not be empty")
+	errSubjectNameStrategyInvalid       = errors.New("subjectNameStrategy must be a *_NAME_STRATEGY constant")
+	errTopicHasNoPartitions             = errors.New("topic has no partitions")
+	errTopicMetadataNotFound            = errors.New("topic metadata not found")
+	errTopicMustNotBeEmpty              = errors.New("topic must not be empty")
+	errTopicsMustNotBeEmpty             = errors.New("topics must not be empty")
+	errTransactionalIDRequired          = errors.New("transactionalId must be set to use transactions")
+	errTransactionalRequiredAcksInvalid = errors.New(
+		"requiredAcks must be -1 when transactionalId is set",
+	)
+	errUnknownBalancer      = errors.New("unknown balancer")
+	errUnknownGroupBalancer = errors.New("unknown group balancer")
+	errURLMustNotBeEmpty    = errors.New("url must not be empty")
 )
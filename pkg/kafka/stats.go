@@ -6,13 +6,16 @@ import (
 )
 
 type kafkaMetrics struct {
-	ReaderDials      *metrics.Metric
-	ReaderFetches    *metrics.Metric
-	ReaderMessages   *metrics.Metric
-	ReaderBytes      *metrics.Metric
-	ReaderRebalances *metrics.Metric
-	ReaderTimeouts   *metrics.Metric
-	ReaderErrors     *metrics.Metric
+	ReaderDials         *metrics.Metric
+	ReaderFetches       *metrics.Metric
+	ReaderMessages      *metrics.Metric
+	ReaderBytes         *metrics.Metric
+	ReaderRebalances    *metrics.Metric
+	ReaderRebalanceTime *metrics.Metric
+	ReaderCommits       *metrics.Metric
+	ReaderFiltered      *metrics.Metric
+	ReaderTimeouts      *metrics.Metric
+	ReaderErrors        *metrics.Metric
 
 	ReaderDialTime   *metrics.Metric
 	ReaderReadTime   *metrics.Metric
@@ -48,6 +51,19 @@ type kafkaMetrics struct {
 	WriterWriteTimeout *metrics.Metric
 	WriterRequiredAcks *metrics.Metric
 	WriterAsync        *metrics.Metric
+
+	WriterTransactionTime    *metrics.Metric
+	WriterTransactionCommits *metrics.Metric
+	WriterTransactionAborts  *metrics.Metric
+
+	BrokerRTT              *metrics.Metric
+	BrokerThrottle         *metrics.Metric
+	BrokerRetries          *metrics.Metric
+	ClientQueueMessages    *metrics.Metric
+	TopicBatchSize         *metrics.Metric
+	TopicBatchBytes        *metrics.Metric
+	PartitionQueueMessages *metrics.Metric
+	PartitionLag           *metrics.Metric
 }
 
 type kafkaMetricDefinition struct {
@@ -104,6 +120,18 @@ var kafkaMetricDefinitions = []kafkaMetricDefinition{
 	metricDef("kafka_reader_rebalance_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
 		km.ReaderRebalances = metric
 	}),
+	typedMetricDef("kafka_reader_rebalance_seconds", metrics.Trend, metrics.Time, func(
+		km *kafkaMetrics,
+		metric *metrics.Metric,
+	) {
+		km.ReaderRebalanceTime = metric
+	}),
+	metricDef("kafka_reader_commit_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.ReaderCommits = metric
+	}),
+	metricDef("kafka_reader_filtered_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.ReaderFiltered = metric
+	}),
 	metricDef("kafka_reader_timeouts_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
 		km.ReaderTimeouts = metric
 	}),
@@ -242,6 +270,51 @@ var kafkaMetricDefinitions = []kafkaMetricDefinition{
 	metricDef("kafka_writer_async", metrics.Rate, func(km *kafkaMetrics, metric *metrics.Metric) {
 		km.WriterAsync = metric
 	}),
+	typedMetricDef("kafka_writer_transaction_seconds", metrics.Trend, metrics.Time, func(
+		km *kafkaMetrics,
+		metric *metrics.Metric,
+	) {
+		km.WriterTransactionTime = metric
+	}),
+	metricDef("kafka_writer_transaction_commit_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.WriterTransactionCommits = metric
+	}),
+	metricDef("kafka_writer_transaction_abort_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.WriterTransactionAborts = metric
+	}),
+	typedMetricDef("kafka_broker_rtt_seconds", metrics.Trend, metrics.Time, func(
+		km *kafkaMetrics,
+		metric *metrics.Metric,
+	) {
+		km.BrokerRTT = metric
+	}),
+	typedMetricDef("kafka_broker_throttle_seconds", metrics.Trend, metrics.Time, func(
+		km *kafkaMetrics,
+		metric *metrics.Metric,
+	) {
+		km.BrokerThrottle = metric
+	}),
+	metricDef("kafka_broker_retries_count", metrics.Counter, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.BrokerRetries = metric
+	}),
+	metricDef("kafka_client_queue_messages", metrics.Gauge, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.ClientQueueMessages = metric
+	}),
+	metricDef("kafka_topic_batch_size", metrics.Trend, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.TopicBatchSize = metric
+	}),
+	typedMetricDef("kafka_topic_batch_bytes", metrics.Trend, metrics.Data, func(
+		km *kafkaMetrics,
+		metric *metrics.Metric,
+	) {
+		km.TopicBatchBytes = metric
+	}),
+	metricDef("kafka_partition_queue_messages", metrics.Gauge, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.PartitionQueueMessages = metric
+	}),
+	metricDef("kafka_partition_lag", metrics.Gauge, func(km *kafkaMetrics, metric *metrics.Metric) {
+		km.PartitionLag = metric
+	}),
 }
 
 func registeredKafkaMetricNames() []string {
@@ -8,10 +8,12 @@ import (
 )
 
 type ConnectionConfig struct {
-	Address string     `json:"address"`
-	Brokers []string   `json:"brokers"`
-	SASL    SASLConfig `json:"sasl"`
-	TLS     TLSConfig  `json:"tls"`
+	Address      string         `json:"address"`
+	Brokers      []string       `json:"brokers"`
+	SASL         SASLConfig     `json:"sasl"`
+	TLS          TLSConfig      `json:"tls"`
+	Config       map[string]any `json:"config"`
+	StrictConfig bool           `json:"strictConfig"`
 }
 
 func (k *Kafka) adminClientClass(call sobek.ConstructorCall) *sobek.Object {
@@ -123,6 +125,280 @@ func (k *Kafka) compatAdminClientClass(
 		common.Throw(runtime, err)
 	}
 
+	err = adminObject.Set("createPartitions", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) < 2 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		topic, ok := call.Argument(0).Export().(string)
+		if !ok {
+			common.Throw(runtime, newInvalidConfigError("topic config", errTopicMustNotBeEmpty))
+		}
+		newTotal := int(call.Argument(1).ToInteger())
+		var assignments [][]int32
+		if assignmentsArg := call.Argument(2); !sobek.IsUndefined(assignmentsArg) && !sobek.IsNull(assignmentsArg) {
+			decodeArgumentList(runtime, assignmentsArg, &assignments, "replica assignments")
+		}
+		var options CreatePartitionsOptions
+		if len(call.Arguments) > 3 && !sobek.IsUndefined(call.Argument(3)) {
+			decodeArgument(runtime, call.Argument(3), &options, "create partitions options")
+		}
+
+		if err := adminClient.CreatePartitions(
+			k.adminContext(), topic, newTotal, assignments, options,
+		); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("waitForPartitions", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) < 2 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		topic, ok := call.Argument(0).Export().(string)
+		if !ok {
+			common.Throw(runtime, newInvalidConfigError("topic config", errTopicMustNotBeEmpty))
+		}
+		count := int(call.Argument(1).ToInteger())
+		var options WaitForPartitionsOptions
+		if len(call.Arguments) > 2 && !sobek.IsUndefined(call.Argument(2)) {
+			decodeArgument(runtime, call.Argument(2), &options, "wait for partitions options")
+		}
+
+		metadata, err := adminClient.WaitForPartitions(k.adminContext(), topic, count, options)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(topicMetadataToJS(metadata))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("describeConfigs", func(call sobek.FunctionCall) sobek.Value {
+		var resources []ConfigResource
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &resources, "config resources")
+
+		descriptions, err := adminClient.DescribeConfigs(k.adminContext(), resources)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(configResourceDescriptionsToJS(descriptions))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("incrementalAlterConfigs", func(call sobek.FunctionCall) sobek.Value {
+		var resources []AlterConfigResource
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &resources, "config resources")
+		var options AlterConfigsOptions
+		if len(call.Arguments) > 1 && !sobek.IsUndefined(call.Argument(1)) {
+			decodeArgument(runtime, call.Argument(1), &options, "alter configs options")
+		}
+
+		if err := adminClient.IncrementalAlterConfigs(k.adminContext(), resources, options); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("listConsumerGroups", func(_ sobek.FunctionCall) sobek.Value {
+		listings, err := adminClient.ListConsumerGroups(k.adminContext())
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(consumerGroupListingsToJS(listings))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("describeConsumerGroups", func(call sobek.FunctionCall) sobek.Value {
+		var groups []string
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &groups, "consumer groups")
+
+		descriptions, err := adminClient.DescribeConsumerGroups(k.adminContext(), groups)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(consumerGroupDescriptionsToJS(descriptions))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("deleteConsumerGroups", func(call sobek.FunctionCall) sobek.Value {
+		var groups []string
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &groups, "consumer groups")
+
+		if err := adminClient.DeleteConsumerGroups(k.adminContext(), groups); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("listConsumerGroupOffsets", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		group, ok := call.Argument(0).Export().(string)
+		if !ok {
+			common.Throw(runtime, newInvalidConfigError("consumer group", errGroupIDMustNotBeEmpty))
+		}
+		var partitions []TopicPartition
+		if len(call.Arguments) > 1 && !sobek.IsUndefined(call.Argument(1)) {
+			decodeArgumentList(runtime, call.Argument(1), &partitions, "consumer group offsets")
+		}
+
+		offsets, err := adminClient.ListConsumerGroupOffsets(k.adminContext(), group, partitions)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(groupOffsetsToJS(offsets))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("alterConsumerGroupOffsets", func(call sobek.FunctionCall) sobek.Value {
+		var partitions []TopicPartition
+		if len(call.Arguments) < 2 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		group, ok := call.Argument(0).Export().(string)
+		if !ok {
+			common.Throw(runtime, newInvalidConfigError("consumer group", errGroupIDMustNotBeEmpty))
+		}
+		decodeArgumentList(runtime, call.Argument(1), &partitions, "consumer group offsets")
+
+		if err := adminClient.AlterConsumerGroupOffsets(k.adminContext(), group, partitions); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("createACLs", func(call sobek.FunctionCall) sobek.Value {
+		var bindings []ACLBinding
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &bindings, "ACL bindings")
+
+		if err := adminClient.CreateACLs(k.adminContext(), bindings); err != nil {
+			common.Throw(runtime, err)
+		}
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("describeACLs", func(call sobek.FunctionCall) sobek.Value {
+		var filter ACLBinding
+		if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Argument(0)) {
+			decodeArgument(runtime, call.Argument(0), &filter, "ACL binding filter")
+		}
+
+		bindings, err := adminClient.DescribeACLs(k.adminContext(), filter)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(aclBindingsToJS(bindings))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("deleteACLs", func(call sobek.FunctionCall) sobek.Value {
+		var filters []ACLBinding
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &filters, "ACL binding filters")
+
+		deleted, err := adminClient.DeleteACLs(k.adminContext(), filters)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(aclBindingsToJS(deleted))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("describeCluster", func(call sobek.FunctionCall) sobek.Value {
+		var options DescribeOptions
+		if len(call.Arguments) > 0 && !sobek.IsUndefined(call.Argument(0)) {
+			decodeArgument(runtime, call.Argument(0), &options, "describe options")
+		}
+
+		description, err := adminClient.DescribeCluster(k.adminContext(), options)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(clusterDescriptionToJS(description))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = adminObject.Set("describeTopics", func(call sobek.FunctionCall) sobek.Value {
+		var topics []string
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		decodeArgumentList(runtime, call.Argument(0), &topics, "topics")
+		var options DescribeOptions
+		if len(call.Arguments) > 1 && !sobek.IsUndefined(call.Argument(1)) {
+			decodeArgument(runtime, call.Argument(1), &options, "describe options")
+		}
+
+		descriptions, err := adminClient.DescribeTopics(k.adminContext(), topics, options)
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+		return runtime.ToValue(topicDescriptionsToJS(descriptions))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
 	err = adminObject.Set("close", func(_ sobek.FunctionCall) sobek.Value {
 		if err := adminClient.Close(); err != nil {
 			common.Throw(runtime, err)
@@ -201,3 +477,143 @@ func topicMetadataToJS(metadata *TopicMetadata) map[string]any {
 		"Error":      metadata.Error,
 	}
 }
+
+func configResourceDescriptionsToJS(descriptions []ConfigResourceDescription) []map[string]any {
+	converted := make([]map[string]any, 0, len(descriptions))
+	for _, description := range descriptions {
+		configs := make([]map[string]any, 0, len(description.Configs))
+		for _, entry := range description.Configs {
+			configs = append(configs, map[string]any{
+				"name":        entry.Name,
+				"value":       entry.Value,
+				"source":      entry.Source,
+				"isDefault":   entry.IsDefault,
+				"isReadOnly":  entry.IsReadOnly,
+				"isSensitive": entry.IsSensitive,
+			})
+		}
+		converted = append(converted, map[string]any{
+			"type":    description.Type,
+			"name":    description.Name,
+			"configs": configs,
+			"error":   description.Error,
+		})
+	}
+	return converted
+}
+
+func consumerGroupListingsToJS(listings []ConsumerGroupListing) []map[string]any {
+	converted := make([]map[string]any, 0, len(listings))
+	for _, listing := range listings {
+		converted = append(converted, map[string]any{
+			"groupId":               listing.GroupID,
+			"state":                 listing.State,
+			"isSimpleConsumerGroup": listing.IsSimpleConsumerGroup,
+		})
+	}
+	return converted
+}
+
+func nodeToJS(node Node) map[string]any {
+	return map[string]any{
+		"id":   node.ID,
+		"host": node.Host,
+		"port": node.Port,
+		"rack": node.Rack,
+	}
+}
+
+func consumerGroupDescriptionsToJS(descriptions []ConsumerGroupDescription) []map[string]any {
+	converted := make([]map[string]any, 0, len(descriptions))
+	for _, description := range descriptions {
+		members := make([]map[string]any, 0, len(description.Members))
+		for _, member := range description.Members {
+			members = append(members, map[string]any{
+				"memberId":        member.MemberID,
+				"groupInstanceId": member.GroupInstanceID,
+				"clientId":        member.ClientID,
+				"host":            member.Host,
+				"assignment":      topicPartitionsToJS(member.Assignment),
+			})
+		}
+		converted = append(converted, map[string]any{
+			"groupId":               description.GroupID,
+			"state":                 description.State,
+			"partitionAssignor":     description.PartitionAssignor,
+			"isSimpleConsumerGroup": description.IsSimpleConsumerGroup,
+			"coordinator":           nodeToJS(description.Coordinator),
+			"members":               members,
+			"error":                 description.Error,
+		})
+	}
+	return converted
+}
+
+func groupOffsetsToJS(offsets []TopicPartition) []map[string]any {
+	converted := topicPartitionsToJS(offsets)
+	for i, offset := range offsets {
+		converted[i]["metadata"] = offset.Metadata
+	}
+	return converted
+}
+
+func aclBindingsToJS(bindings []ACLBinding) []map[string]any {
+	converted := make([]map[string]any, 0, len(bindings))
+	for _, binding := range bindings {
+		converted = append(converted, map[string]any{
+			"resourceType":   binding.ResourceType,
+			"resourceName":   binding.ResourceName,
+			"patternType":    binding.PatternType,
+			"principal":      binding.Principal,
+			"host":           binding.Host,
+			"operation":      binding.Operation,
+			"permissionType": binding.PermissionType,
+		})
+	}
+	return converted
+}
+
+func nodesToJS(nodes []Node) []map[string]any {
+	converted := make([]map[string]any, 0, len(nodes))
+	for _, node := range nodes {
+		converted = append(converted, nodeToJS(node))
+	}
+	return converted
+}
+
+func clusterDescriptionToJS(description *ClusterDescription) map[string]any {
+	return map[string]any{
+		"clusterId":            description.ClusterID,
+		"controllerId":         description.ControllerID,
+		"brokers":              nodesToJS(description.Brokers),
+		"authorizedOperations": description.AuthorizedOperations,
+	}
+}
+
+func topicDescriptionsToJS(descriptions []TopicDescription) []map[string]any {
+	converted := make([]map[string]any, 0, len(descriptions))
+	for _, description := range descriptions {
+		partitions := make([]map[string]any, 0, len(description.Partitions))
+		for _, partition := range description.Partitions {
+			var leader any
+			if partition.Leader != nil {
+				leader = nodeToJS(*partition.Leader)
+			}
+			partitions = append(partitions, map[string]any{
+				"id":       partition.ID,
+				"leader":   leader,
+				"replicas": nodesToJS(partition.Replicas),
+				"isr":      nodesToJS(partition.Isr),
+			})
+		}
+		converted = append(converted, map[string]any{
+			"topic":                description.Topic,
+			"topicId":              description.TopicID,
+			"isInternal":           description.IsInternal,
+			"partitions":           partitions,
+			"authorizedOperations": description.AuthorizedOperations,
+			"error":                description.Error,
+		})
+	}
+	return converted
+}
@@ -0,0 +1,230 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.k6.io/k6/js/common"
+	"go.k6.io/k6/metrics"
+)
+
+// InitTransactions registers the transactional.id with the cluster and fences
+// out any previous producer instance that used the same id.
+func (p *Producer) InitTransactions(ctx context.Context) error {
+	if err := p.ensureTransactional(); err != nil {
+		return err
+	}
+	ctx = ensureContext(ctx)
+
+	if err := p.client.InitTransactions(ctx); err != nil {
+		return transactionError(failedInitTransactions, "Failed to initialize transactions.", err)
+	}
+
+	return nil
+}
+
+func (p *Producer) BeginTransaction() error {
+	if err := p.ensureTransactional(); err != nil {
+		return err
+	}
+
+	if err := p.client.BeginTransaction(); err != nil {
+		return transactionError(failedBeginTransaction, "Failed to begin transaction.", err)
+	}
+
+	p.txnMu.Lock()
+	p.txnStartedAt = time.Now()
+	p.txnMu.Unlock()
+
+	return nil
+}
+
+// CommitTransaction commits the current transaction and returns how long it was open.
+func (p *Producer) CommitTransaction(ctx context.Context) (time.Duration, error) {
+	if err := p.ensureTransactional(); err != nil {
+		return 0, err
+	}
+	ctx = ensureContext(ctx)
+
+	if err := p.client.CommitTransaction(ctx); err != nil {
+		return 0, transactionError(failedCommitTransaction, "Failed to commit transaction.", err)
+	}
+
+	return p.endTransaction(), nil
+}
+
+// AbortTransaction aborts the current transaction and returns how long it was open.
+func (p *Producer) AbortTransaction(ctx context.Context) (time.Duration, error) {
+	if err := p.ensureTransactional(); err != nil {
+		return 0, err
+	}
+	ctx = ensureContext(ctx)
+
+	if err := p.client.AbortTransaction(ctx); err != nil {
+		return 0, transactionError(failedAbortTransaction, "Failed to abort transaction.", err)
+	}
+
+	return p.endTransaction(), nil
+}
+
+// SendOffsetsToTransaction adds the consumer's current positions to the
+// transaction, so they are committed atomically with the produced messages.
+func (p *Producer) SendOffsetsToTransaction(ctx context.Context, consumer *Consumer) error {
+	if err := p.ensureTransactional(); err != nil {
+		return err
+	}
+	if consumer == nil {
+		return newMissingConfigError("consumer")
+	}
+	ctx = ensureContext(ctx)
+
+	offsets, groupMetadata, err := consumer.transactionOffsets()
+	if err != nil {
+		return err
+	}
+	if len(offsets) == 0 {
+		return nil
+	}
+
+	if err := p.client.SendOffsetsToTransaction(ctx, offsets, groupMetadata); err != nil {
+		return transactionError(failedSendOffsetsToTransaction, "Failed to send offsets to transaction.", err)
+	}
+
+	return nil
+}
+
+func (p *Producer) ensureTransactional() error {
+	if p == nil || p.client == nil {
+		return newMissingConfigError("producer")
+	}
+	if p.transactionalID == "" {
+		return newInvalidConfigError("producer", errTransactionalIDRequired)
+	}
+
+	return nil
+}
+
+func (p *Producer) endTransaction() time.Duration {
+	p.txnMu.Lock()
+	defer p.txnMu.Unlock()
+
+	if p.txnStartedAt.IsZero() {
+		return 0
+	}
+
+	elapsed := time.Since(p.txnStartedAt)
+	p.txnStartedAt = time.Time{}
+	return elapsed
+}
+
+// transactionOffsets returns the positions of all assigned partitions together
+// with the group metadata the transaction coordinator needs to commit them.
+func (c *Consumer) transactionOffsets() ([]ckafka.TopicPartition, *ckafka.ConsumerGroupMetadata, error) {
+	client, err := c.beginOperation()
+	if err != nil {
+		if errors.Is(err, errConsumerClosing) {
+			return nil, nil, NewXk6KafkaError(
+				failedSendOffsetsToTransaction, "Failed to read consumer positions.", err)
+		}
+		return nil, nil, err
+	}
+	defer c.endOperation()
+
+	assignment, err := client.Assignment()
+	if err != nil {
+		return nil, nil, NewXk6KafkaError(
+			failedSendOffsetsToTransaction, "Failed to read consumer assignment.", err)
+	}
+
+	positions, err := client.Position(assignment)
+	if err != nil {
+		return nil, nil, NewXk6KafkaError(
+			failedSendOffsetsToTransaction, "Failed to read consumer positions.", err)
+	}
+
+	offsets := make([]ckafka.TopicPartition, 0, len(positions))
+	for _, position := range positions {
+		// Partitions without a consumed message have no position to commit.
+		if position.Offset < 0 {
+			continue
+		}
+		offsets = append(offsets, position)
+	}
+
+	groupMetadata, err := client.GetConsumerGroupMetadata()
+	if err != nil {
+		return nil, nil, NewXk6KafkaError(
+			failedSendOffsetsToTransaction, "Failed to read consumer group metadata.", err)
+	}
+
+	return offsets, groupMetadata, nil
+}
+
+// transactionError maps librdkafka transactional error classes to typed codes,
+// so scripts can tell whether to abort and retry or to recreate the producer.
+func transactionError(code errCode, msg string, err error) *Xk6KafkaError {
+	var kafkaErr ckafka.Error
+	if errors.As(err, &kafkaErr) {
+		switch {
+		case kafkaErr.IsFatal():
+			code = transactionFatal
+		case kafkaErr.TxnRequiresAbort():
+			code = transactionAbortable
+		case kafkaErr.IsRetriable():
+			code = transactionRetriable
+		}
+	}
+
+	return NewXk6KafkaError(code, msg, err)
+}
+
+func (k *Kafka) reportProducerTransactionMetrics(producer *Producer, elapsed time.Duration, aborted bool) {
+	state := k.vu.State()
+	if state == nil {
+		logger.WithField("error", ErrForbiddenInInitContext).Error(ErrForbiddenInInitContext)
+		common.Throw(k.vu.Runtime(), ErrForbiddenInInitContext)
+	}
+
+	ctx := k.vu.Context()
+	if ctx == nil {
+		err := NewXk6KafkaError(cannotReportStats, "Cannot report transaction stats, no context.", nil)
+		logger.WithField("error", err).Info(err)
+		common.Throw(k.vu.Runtime(), err)
+	}
+
+	ctm := state.Tags.GetCurrentValues()
+	sampleTags := ctm.Tags.With("transactionalid", producer.transactionalID)
+
+	outcome := k.metrics.WriterTransactionCommits
+	if aborted {
+		outcome = k.metrics.WriterTransactionAborts
+	}
+
+	now := time.Now()
+	metrics.PushIfNotDone(ctx, state.Samples, metrics.ConnectedSamples{
+		Samples: []metrics.Sample{
+			{
+				Time: now,
+				TimeSeries: metrics.TimeSeries{
+					Metric: k.metrics.WriterTransactionTime,
+					Tags:   sampleTags,
+				},
+				Value:    metrics.D(elapsed),
+				Metadata: ctm.Metadata,
+			},
+			{
+				Time: now,
+				TimeSeries: metrics.TimeSeries{
+					Metric: outcome,
+					Tags:   sampleTags,
+				},
+				Value:    1,
+				Metadata: ctm.Metadata,
+			},
+		},
+		Tags: sampleTags,
+		Time: now,
+	})
+}
@@ -0,0 +1,166 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ckafka "github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/grafana/sobek"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestTransactionUnknown = errors.New("unknown")
+
+func TestWriterConfigToConfluentConfigMapTransactional(t *testing.T) {
+	config, err := writerConfigToConfluentConfigMap(&WriterConfig{
+		Brokers:         []string{"localhost:9092"},
+		TransactionalID: "txn-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "txn-1", config["transactional.id"])
+	assert.Equal(t, "all", config["acks"])
+
+	_, err = writerConfigToConfluentConfigMap(&WriterConfig{
+		Brokers:         []string{"localhost:9092"},
+		TransactionalID: "txn-1",
+		RequiredAcks:    1,
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTransactionalRequiredAcksInvalid)
+
+	// An explicit 0 is not taken for the unset default.
+	writerConfig := &WriterConfig{}
+	require.NoError(t, writerConfig.Parse(map[string]any{
+		"brokers":         []string{"localhost:9092"},
+		"transactionalId": "txn-1",
+		"requiredAcks":    0,
+	}, sobek.New()))
+	_, err = writerConfigToConfluentConfigMap(writerConfig)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTransactionalRequiredAcksInvalid)
+}
+
+func TestProducerWaitsForAckWhenTransactional(t *testing.T) {
+	assert.True(t, producerWaitsForAck(&WriterConfig{TransactionalID: "txn-1"}))
+}
+
+func TestProducerTransactionsRequireTransactionalID(t *testing.T) {
+	var nilProducer *Producer
+	require.Error(t, nilProducer.BeginTransaction())
+
+	producer := &Producer{client: &ckafka.Producer{}}
+	err := producer.BeginTransaction()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errTransactionalIDRequired)
+}
+
+func TestTransactionErrorClassification(t *testing.T) {
+	fatal := transactionError(
+		failedCommitTransaction,
+		"Failed to commit transaction.",
+		ckafka.NewError(ckafka.ErrFenced, "fenced", true),
+	)
+	assert.Equal(t, transactionFatal, fatal.Code)
+
+	plain := transactionError(
+		failedCommitTransaction,
+		"Failed to commit transaction.",
+		ckafka.NewError(ckafka.ErrState, "state", false),
+	)
+	assert.Equal(t, failedCommitTransaction, plain.Code)
+
+	other := transactionError(failedAbortTransaction, "Failed to abort transaction.", errTestTransactionUnknown)
+	assert.Equal(t, failedAbortTransaction, other.Code)
+	assert.ErrorIs(t, other, errTestTransactionUnknown)
+}
+
+func TestProducerTransactionsWithMockCluster(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(3)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	topicName := "transaction-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:         []string{mockCluster.BootstrapServers()},
+		Topic:           topicName,
+		TransactionalID: "xk6-kafka-transaction-test",
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	require.NoError(t, producer.InitTransactions(ctx))
+
+	require.NoError(t, producer.BeginTransaction())
+	require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte("committed")}}))
+	elapsed, err := producer.CommitTransaction(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, elapsed)
+
+	require.NoError(t, producer.BeginTransaction())
+	require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte("aborted")}}))
+	elapsed, err = producer.AbortTransaction(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, elapsed)
+}
+
+func TestConsumerIsolationLevelWithMockCluster(t *testing.T) {
+	mockCluster, err := ckafka.NewMockCluster(3)
+	require.NoError(t, err)
+	defer mockCluster.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	topicName := "isolation-topic"
+	require.NoError(t, mockCluster.CreateTopic(topicName, 1, 1))
+
+	producer, err := NewProducerFromWriterConfig(&WriterConfig{
+		Brokers:         []string{mockCluster.BootstrapServers()},
+		Topic:           topicName,
+		TransactionalID: "xk6-kafka-isolation-test",
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, producer.Close())
+	}()
+
+	require.NoError(t, producer.InitTransactions(ctx))
+	require.NoError(t, producer.BeginTransaction())
+	require.NoError(t, producer.Produce(ctx, []Message{{Value: []byte("committed")}}))
+	_, err = producer.CommitTransaction(ctx)
+	require.NoError(t, err)
+
+	// The mock cluster does not report aborted transactions in fetch
+	// responses, so only the committed path can be checked here.
+	for _, isolationLevel := range []string{"", isolationLevelReadCommitted, isolationLevelReadUncommitted} {
+		consumer, err := NewConsumerFromReaderConfig(&ReaderConfig{
+			Brokers:        []string{mockCluster.BootstrapServers()},
+			Topic:          topicName,
+			StartOffset:    firstOffset,
+			IsolationLevel: isolationLevel,
+		})
+		require.NoError(t, err)
+
+		messages, err := consumer.Consume(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, messages, 1)
+		assert.Equal(t, []byte("committed"), messages[0].Value)
+
+		expected := isolationLevel
+		if expected == "" {
+			expected = isolationLevelReadCommitted
+		}
+		assert.Equal(t, expected, consumer.Stats().IsolationLevel)
+		require.NoError(t, consumer.Close())
+	}
+}
@@ -3,6 +3,8 @@ package kafka
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/grafana/sobek"
 	"go.k6.io/k6/js/common"
@@ -44,6 +46,58 @@ func decodeArgument(runtime *sobek.Runtime, value sobek.Value, target any, compo
 	decodeArgumentMap(runtime, exportArgumentMap(runtime, value, component), target, component)
 }
 
+// exportTimeArgument accepts a Date, an RFC 3339 string or milliseconds since
+// the Unix epoch.
+func exportTimeArgument(runtime *sobek.Runtime, value sobek.Value, component string) time.Time {
+	switch exported := value.Export().(type) {
+	case time.Time:
+		return exported
+	case string:
+		parsed, err := time.Parse(time.RFC3339Nano, exported)
+		if err != nil {
+			throwConfigError(runtime, newInvalidConfigError(component, err))
+		}
+		return parsed
+	case int64:
+		return time.UnixMilli(exported)
+	case float64:
+		return time.UnixMilli(int64(exported))
+	default:
+		throwConfigError(runtime, newInvalidConfigError(
+			component,
+			fmt.Errorf("%w, got %T", errExpectedTime, exported),
+		))
+		return time.Time{}
+	}
+}
+
+// decodeArgumentList decodes a JS array argument into target, a pointer to a
+// slice.
+func decodeArgumentList(runtime *sobek.Runtime, value sobek.Value, target any, component string) {
+	exported := value.Export()
+	if exported == nil {
+		throwConfigError(runtime, newMissingConfigError(component))
+		return
+	}
+	if kind := reflect.TypeOf(exported).Kind(); kind != reflect.Slice && kind != reflect.Array {
+		throwConfigError(runtime, newInvalidConfigError(
+			component,
+			fmt.Errorf("%w, got %T", errExpectedArray, exported),
+		))
+		return
+	}
+
+	b, err := json.Marshal(exported)
+	if err != nil {
+		throwConfigError(runtime, newInvalidConfigError(component, err))
+		return
+	}
+
+	if err := json.Unmarshal(b, target); err != nil {
+		throwConfigError(runtime, newInvalidConfigError(component, err))
+	}
+}
+
 func decodeArgumentMap(runtime *sobek.Runtime, params map[string]any, target any, component string) {
 	if params == nil {
 		throwConfigError(runtime, newMissingConfigError(component))
@@ -44,6 +44,10 @@ type WriterConfig struct {
 	BatchBytes      int             `mapstructure:"batchBytes"`
 	RequiredAcks    int             `mapstructure:"requiredAcks"`
 	Topic           string          `mapstructure:"topic"`
+	TransactionalID string          `mapstructure:"transactionalId"`
+	MaxInFlight     int             `mapstructure:"maxInFlight"`
+	Config          map[string]any  `mapstructure:"config"`
+	StrictConfig    bool            `mapstructure:"strictConfig"`
 	Balancer        string          `mapstructure:"-"`
 	BalancerFunc    BalancerKeyFunc `mapstructure:"-"`
 	Compression     string          `mapstructure:"compression"`
@@ -53,6 +57,13 @@ type WriterConfig struct {
 	WriteTimeout    time.Duration   `mapstructure:"writeTimeout"`
 	SASL            SASLConfig      `mapstructure:"sasl"`
 	TLS             TLSConfig       `mapstructure:"tls"`
+	// KeySerializer and ValueSerializer encode the keys and values passed to
+	// produce.
+	KeySerializer   *SerializerConfig `mapstructure:"keySerializer"`
+	ValueSerializer *SerializerConfig `mapstructure:"valueSerializer"`
+
+	// requiredAcksSet tells an explicit requiredAcks of 0 from an unset one.
+	requiredAcksSet bool
 }
 
 func (c *WriterConfig) Parse(m map[string]any, runtime *sobek.Runtime) error {
@@ -77,18 +88,23 @@ func (c *WriterConfig) Parse(m map[string]any, runtime *sobek.Runtime) error {
 	if err := decoder.Decode(m); err != nil {
 		return fmt.Errorf("failed to decode writer config: %w", err)
 	}
+	c.requiredAcksSet = m["requiredAcks"] != nil
 	if c.Balancer != "" {
 		if _, ok := supportedBalancers[c.Balancer]; !ok {
 			return fmt.Errorf("%w %q", errUnknownBalancer, c.Balancer)
 		}
 	}
+	if c.MaxInFlight < 0 {
+		return errMaxInFlightInvalid
+	}
 	return nil
 }
 
 type Message struct {
 	Topic string `json:"topic"`
 
-	// Setting Partition has no effect when writing messages.
+	// Partition is honored when writing messages only if it was set
+	// explicitly; otherwise the balancer or librdkafka picks it.
 	Partition     int            `json:"partition"`
 	Offset        int64          `json:"offset"`
 	HighWaterMark int64          `json:"highWaterMark"`
@@ -99,10 +115,19 @@ type Message struct {
 	// If not set at the creation, Time will be automatically set when
 	// writing the message.
 	Time time.Time `json:"time"`
+
+	partitionSet bool
+	// decodedKey and decodedValue are set by the deserializers of the
+	// consumer and returned instead of Key and Value.
+	decodedKey   any
+	decodedValue any
 }
 
 type ProduceConfig struct {
 	Messages []Message `json:"messages"`
+	// DeliveryReports makes produce wait for every message and return where
+	// each one was stored.
+	DeliveryReports bool `json:"deliveryReports"`
 }
 
 func (k *Kafka) producerClass(call sobek.ConstructorCall) *sobek.Object {
@@ -127,9 +152,6 @@ func (k *Kafka) compatProducerClass(call sobek.ConstructorCall) *sobek.Object {
 	if err != nil {
 		throwConfigError(runtime, newInvalidConfigError("writer config", err))
 	}
-	if err := validateConfluentWriterCompatibility(&writerConfig); err != nil {
-		common.Throw(runtime, err)
-	}
 	producer, err := NewProducerFromWriterConfig(&writerConfig)
 	if err != nil {
 		common.Throw(runtime, err)
@@ -146,18 +168,37 @@ func (k *Kafka) compatProducerClass(call sobek.ConstructorCall) *sobek.Object {
 			common.Throw(runtime, ErrNotEnoughArguments)
 		}
 
-		producerConfig = decodeProduceConfig(runtime, call.Argument(0))
+		producerConfig = decodeProduceConfig(runtime, call.Argument(0), producer)
 		if producerConfig == nil {
 			return sobek.Undefined()
 		}
 
-		k.produceWithProducer(producer, producerConfig)
+		reports := k.produceWithProducer(producer, producerConfig)
+		if producerConfig.DeliveryReports {
+			return runtime.ToValue(deliveryReportsToJS(reports))
+		}
 		return sobek.Undefined()
 	})
 	if err != nil {
 		common.Throw(runtime, err)
 	}
 
+	err = producerObject.Set("produceAsync", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		producerConfig := decodeProduceConfig(runtime, call.Argument(0), producer)
+		if producerConfig == nil {
+			return sobek.Undefined()
+		}
+
+		return runtime.ToValue(k.produceAsyncWithProducer(producer, producerConfig))
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
 	err = producerObject.Set("flush", func(_ sobek.FunctionCall) sobek.Value {
 		if ctx := k.vu.Context(); ctx != nil {
 			if err := producer.Flush(ctx); err != nil {
@@ -183,6 +224,70 @@ func (k *Kafka) compatProducerClass(call sobek.ConstructorCall) *sobek.Object {
 		common.Throw(runtime, err)
 	}
 
+	err = producerObject.Set("initTransactions", func(_ sobek.FunctionCall) sobek.Value {
+		if err := producer.InitTransactions(ensureContext(k.vu.Context())); err != nil {
+			common.Throw(runtime, err)
+		}
+
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = producerObject.Set("beginTransaction", func(_ sobek.FunctionCall) sobek.Value {
+		if err := producer.BeginTransaction(); err != nil {
+			common.Throw(runtime, err)
+		}
+
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = producerObject.Set("commitTransaction", func(_ sobek.FunctionCall) sobek.Value {
+		elapsed, err := producer.CommitTransaction(ensureContext(k.vu.Context()))
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+
+		k.reportProducerTransactionMetrics(producer, elapsed, false)
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = producerObject.Set("abortTransaction", func(_ sobek.FunctionCall) sobek.Value {
+		elapsed, err := producer.AbortTransaction(ensureContext(k.vu.Context()))
+		if err != nil {
+			common.Throw(runtime, err)
+		}
+
+		k.reportProducerTransactionMetrics(producer, elapsed, true)
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
+	err = producerObject.Set("sendOffsetsToTransaction", func(call sobek.FunctionCall) sobek.Value {
+		if len(call.Arguments) == 0 {
+			common.Throw(runtime, ErrNotEnoughArguments)
+		}
+
+		consumer := exportConsumer(runtime, call.Argument(0))
+		if err := producer.SendOffsetsToTransaction(ensureContext(k.vu.Context()), consumer); err != nil {
+			common.Throw(runtime, err)
+		}
+
+		return sobek.Undefined()
+	})
+	if err != nil {
+		common.Throw(runtime, err)
+	}
+
 	err = producerObject.Set("close", func(_ sobek.FunctionCall) sobek.Value {
 		if err := producer.Close(); err != nil {
 			common.Throw(runtime, err)
@@ -201,11 +306,18 @@ func (k *Kafka) compatProducerClass(call sobek.ConstructorCall) *sobek.Object {
 	return runtime.ToValue(producerObject).ToObject(runtime)
 }
 
-func decodeProduceConfig(runtime *sobek.Runtime, value sobek.Value) *ProduceConfig {
+func decodeProduceConfig(runtime *sobek.Runtime, value sobek.Value, producer *Producer) *ProduceConfig {
 	params := exportArgumentMap(runtime, value, "produce config")
 	if params == nil {
 		return nil
 	}
+	if messages, ok := params["messages"].([]any); ok {
+		if err := producer.serializeMessages(messages); err != nil {
+			logger.WithField("error", err).Error(err)
+			common.Throw(runtime, err)
+			return nil
+		}
+	}
 
 	var produceConfig ProduceConfig
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
@@ -245,32 +357,28 @@ func decodeProduceConfig(runtime *sobek.Runtime, value sobek.Value) *ProduceConf
 		return nil
 	}
 
-	return &produceConfig
-}
-
-func validateConfluentWriterCompatibility(writerConfig *WriterConfig) *Xk6KafkaError {
-	if writerConfig == nil {
-		return newMissingConfigError("writer config")
-	}
-	if writerConfig.Balancer != "" || writerConfig.BalancerFunc != nil {
-		return NewXk6KafkaError(
-			unsupportedOperation,
-			"Writer balancer configuration is not supported on the Confluent compatibility path.",
-			nil,
-		)
+	// A zero partition is a valid target, so only messages that set the key
+	// are pinned to it. Undefined and null, which scripts get by spreading
+	// optional fields, leave the partition to the balancer.
+	if messages, ok := params["messages"].([]any); ok {
+		for i, message := range messages {
+			if fields, ok := message.(map[string]any); ok && i < len(produceConfig.Messages) {
+				produceConfig.Messages[i].partitionSet = fields["partition"] != nil
+			}
+		}
 	}
 
-	return nil
+	return &produceConfig
 }
 
-func (k *Kafka) produceWithProducer(producer *Producer, produceConfig *ProduceConfig) {
+func (k *Kafka) produceWithProducer(producer *Producer, produceConfig *ProduceConfig) []DeliveryReport {
 	if producer == nil {
 		throwConfigError(k.vu.Runtime(), newMissingConfigError("producer"))
-		return
+		return nil
 	}
 	if produceConfig == nil {
 		throwConfigError(k.vu.Runtime(), newMissingConfigError("produce config"))
-		return
+		return nil
 	}
 
 	if state := k.vu.State(); state == nil {
@@ -285,12 +393,35 @@ func (k *Kafka) produceWithProducer(producer *Producer, produceConfig *ProduceCo
 		common.Throw(k.vu.Runtime(), err)
 	}
 
+	var reports []DeliveryReport
+	var err error
 	startedAt := time.Now()
-	if err := producer.Produce(ctx, produceConfig.Messages); err != nil {
+	if produceConfig.DeliveryReports {
+		reports, err = producer.ProduceWithReports(ctx, produceConfig.Messages)
+	} else {
+		err = producer.Produce(ctx, produceConfig.Messages)
+	}
+	if err != nil {
 		k.reportProducerCompatibilityMetrics(producer, produceConfig.Messages, time.Since(startedAt), err)
 		logger.WithField("error", err).Error(err)
 		common.Throw(k.vu.Runtime(), err)
 	}
 
 	k.reportProducerCompatibilityMetrics(producer, produceConfig.Messages, time.Since(startedAt), nil)
+	return reports
+}
+
+func deliveryReportsToJS(reports []DeliveryReport) []map[string]any {
+	converted := make([]map[string]any, 0, len(reports))
+	for _, report := range reports {
+		converted = append(converted, map[string]any{
+			"topic":     report.Topic,
+			"partition": report.Partition,
+			"offset":    report.Offset,
+			"time":      report.Time.Format(time.RFC3339Nano),
+			"latency":   float64(report.Latency) / float64(time.Millisecond),
+		})
+	}
+
+	return converted
 }
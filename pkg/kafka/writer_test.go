@@ -377,6 +377,7 @@ func TestWriterConfigParse(t *testing.T) {
 				ClientKeyPem:          "key-pem-content",
 				ServerCaPem:           "ca-pem-content",
 			},
+			requiredAcksSet: true,
 		}, writerConfig)
 	})
 
@@ -417,7 +418,7 @@ func TestWriterConfigParse(t *testing.T) {
 
 		// Test that the balancer function works correctly
 		testKey := []byte("test-key")
-		partition := writerConfig.BalancerFunc(testKey, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+		partition := writerConfig.BalancerFunc(testKey, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
 		assert.Equal(t, 5, partition)
 	})
 